@@ -17,7 +17,7 @@ package app
 import (
 	"fmt"
 	_ "image/png"
-	"math/rand"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,12 +33,14 @@ import (
 	"github.com/quartermeat/card_game/input"
 	"github.com/quartermeat/card_game/objects"
 	"github.com/quartermeat/card_game/observable"
+	"github.com/quartermeat/card_game/rules"
+	"github.com/quartermeat/card_game/strategy"
 	"github.com/quartermeat/card_game/ui"
 )
 
 // AppRun() is the main game function and main loop for a card game.
 // It sets up the window configuration, initializes the GUI, loads assets,
-// seeds the random number generator, and starts a command server.
+// seeds the random number generator from -seed, and starts a command server.
 // It then enters a loop that handles delta time, handles input, updates game objects,
 // draws game objects, draws the GUI, and draws a cursor based on selected object.
 // At the end of each loop it also updates the window title with FPS and number of game objects.
@@ -46,9 +48,12 @@ import (
 func AppRun() {
 
 	StateManager := gamestates.NewStateManager()
-		
-	//seed rng
-	rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	//seed rng, log it so the game can be replayed with -seed
+	if !seedSet() {
+		*Seed = time.Now().UnixNano()
+	}
+	fmt.Printf("seed: %d\n", *Seed)
 
 	cfg := pixelgl.WindowConfig{
 		Title:       card_game_rules.APP_TITLE,
@@ -79,6 +84,7 @@ func AppRun() {
 		sysErrors          []error
 		consoleToInputChan chan console.ITxTopic
 		gui                ui.GUI
+		game               *rules.GameState
 	)
 
 	// Replace the path with the path to your wooden texture image
@@ -106,10 +112,38 @@ func AppRun() {
 	// load assets
 	objectAssets = card_game_rules.LoadAssets(sysErrors)
 
+	// card definitions have to match the art that was just loaded
+	cardDefinitions, err := card_game_rules.LoadCardDefinitions()
+	if err != nil {
+		panic(err)
+	}
+	game = rules.NewGameState(cardDefinitions, StateManager, *Seed)
+	kingdomConfig, err := KingdomConfig()
+	if err != nil {
+		panic(err)
+	}
+	kingdom, err := game.ChooseKingdom(kingdomConfig)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("kingdom: %s\n", strings.Join(kingdom, ", "))
+	seats, err := SeatConfig(cardDefinitions)
+	if err != nil {
+		panic(err)
+	}
+	// people answer decisions through the overlay, each AI seat's strategy answers its own
+	for seat, bot := range seats {
+		if bot == nil {
+			game.AddPlayer(fmt.Sprintf("player%d", seat+1), true)
+			continue
+		}
+		game.AddPlayer(fmt.Sprintf("ai%d", seat+1), false)
+		game.SetDecider(seat, bot)
+	}
+
 	last := time.Now()
 	
 	for !win.Closed() {
-		StateManager.SetCurrentState(gamestates.Init)
 		//handle delta
 		dt := time.Since(last).Seconds()
 		last = time.Now()
@@ -131,6 +165,8 @@ func AppRun() {
 			&drawHitBox,
 			consoleToInputChan,
 			debugLog,
+			game,
+			StateManager,
 		)
 		
 		var waitGroup sync.WaitGroup
@@ -143,22 +179,23 @@ func AppRun() {
 			}
 		}
 		case gamestates.Ready:{
-			// randomly choose first player
-			// set currentState to that player's turn
-			StateManager.SetCurrentState(gamestates.PlayerTurn)
+			// deal opening hands and start the first player's turn
+			game.Start()
 		}
 		case gamestates.PlayerTurn:{
-			// check for player actions that can be taken, track actions that have been taken
-			//Turn Phases:
-			// Action Phase
-			// Buy Phase
-			// Cleanup Phase
-			StateManager.SetCurrentState(gamestates.AiTurn)
+			// the player ends each phase through input
 		}
 		case gamestates.AiTurn:{
-			// randomly choose first player
-			// set currentState to that player's turn
-			StateManager.SetCurrentState(gamestates.PlayerTurn)
+			// the seat's strategy plays its whole turn at once, waiting while a human seat owes a decision
+			if decision := game.PendingDecision(); decision != nil && seats[decision.Player] == nil {
+				break
+			}
+			if err := strategy.TakeTurn(game, seats[StateManager.GetSeat()]); err != nil && err != rules.ErrDecisionPending {
+				debugLog = append(debugLog, debuglog.Entry{Message: err.Error()})
+			}
+		}
+		case gamestates.GameOver:{
+			// the final standings are drawn over the table
 		}
 		default:{
 			//do nothing
@@ -187,6 +224,10 @@ func AppRun() {
 		//hide GUI for now
 		// gui.DrawGUI(win, &cam)
 
+		if StateManager.GetCurrentState() == gamestates.GameOver {
+			ui.DrawGameOver(win, &cam, game)
+		}
+
 		//draw cursor based on selected object
 		//must be done outside of inputHandler to be the last thing drawn
 		if win.MouseInsideWindow() {
@@ -204,18 +245,27 @@ func AppRun() {
 		frames++
 		select {
 		case <-second.C:
-			win.SetTitle(fmt.Sprintf("%s | FPS: %d | GameObjects: %d", cfg.Title, frames, len(gameObjs)))
+			if StateManager.GetCurrentState() == gamestates.GameOver {
+				win.SetTitle(fmt.Sprintf("%s | FPS: %d | GameObjects: %d | Game Over | %s", cfg.Title, frames, len(gameObjs),
+					strings.Join(ui.GameOverLines(game)[1:], " | ")))
+				frames = 0
+				break
+			}
+			win.SetTitle(fmt.Sprintf("%s | FPS: %d | GameObjects: %d | Turn: %s | Phase: %s | Actions: %d | Buys: %d | Coins: %d", cfg.Title, frames, len(gameObjs),
+				game.CurrentPlayer().Name, StateManager.GetPhase(), StateManager.GetActions(), StateManager.GetBuys(), StateManager.GetCoins()))
 			frames = 0
 		default:
 		}
 
 		for _, entry := range debugLog {
-			fmt.Printf("debugLog: %s", entry.GetMessage())
+			fmt.Printf("debugLog: %s\n", entry.GetMessage())
 			if entry.GetMessage() == console.Stop {
 				//give time for graphics stuff finish
 				time.Sleep(2 * time.Second)
 				win.Destroy()
 			}
 		}
+		// each entry is printed once, so the log doesn't grow with every frame
+		debugLog = debugLog[:0]
 	}
 }
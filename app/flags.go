@@ -0,0 +1,88 @@
+package app
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/quartermeat/card_game/rules"
+	"github.com/quartermeat/card_game/strategy"
+)
+
+// command line settings for a game
+var (
+	// Seed is the seed every shuffle and random choice of the game is made from, set with -seed to replay a game
+	Seed = flag.Int64("seed", 0, "seed for the game's random number generator, random if not set")
+	// Kingdom is a preset name or a comma separated list of 10 kingdom cards, random if not set
+	Kingdom    = flag.String("kingdom", "", "kingdom preset or comma separated list of kingdom cards, random if not set")
+	Require    = flag.String("require", "", "comma separated kingdom cards a random kingdom must include")
+	Ban        = flag.String("ban", "", "comma separated kingdom cards a random kingdom must not include")
+	MaxAttacks = flag.Int("max-attacks", -1, "most attack cards a random kingdom can have, no limit if negative")
+	MinActions = flag.Int("min-actions", 1, "fewest +actions cards a random kingdom can have")
+	// Seats is who plays each seat in turn order, a person or a strategy from the strategy package
+	Seats = flag.String("seats", "human,ai", "comma separated human, ai or a strategy like random, big_money:reload, priority:<file> or mcts:2s for each seat in turn order, 2 to 4 seats")
+)
+
+// seedSet checks if -seed was given on the command line
+func seedSet() bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			set = true
+		}
+	})
+	return set
+}
+
+// KingdomConfig builds the kingdom selection from the command line
+func KingdomConfig() (rules.KingdomConfig, error) {
+	config := rules.KingdomConfig{
+		Required: splitNames(*Require),
+		Banned:   splitNames(*Ban),
+	}
+	if names := splitNames(*Kingdom); len(names) == 1 {
+		config.Preset = names[0]
+	} else {
+		config.Cards = names
+	}
+	if (config.Preset != "" || len(config.Cards) > 0) && (len(config.Required) > 0 || len(config.Banned) > 0) {
+		return config, errors.New("-require and -ban only apply to random kingdoms")
+	}
+	if *MinActions > 0 {
+		config.Rules = append(config.Rules, rules.AtLeastActions(*MinActions))
+	}
+	if *MaxAttacks >= 0 {
+		config.Rules = append(config.Rules, rules.AtMostAttacks(*MaxAttacks))
+	}
+	return config, nil
+}
+
+func splitNames(list string) []string {
+	names := make([]string, 0)
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// SeatConfig returns the strategy playing each seat from the command line, nil for a person
+func SeatConfig(definitions rules.CardDefinitions) ([]strategy.Strategy, error) {
+	seats := make([]strategy.Strategy, 0)
+	for _, seat := range splitNames(*Seats) {
+		if seat == "human" {
+			seats = append(seats, nil)
+			continue
+		}
+		bot, err := strategy.New(seat, definitions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "seat %d", len(seats)+1)
+		}
+		seats = append(seats, bot)
+	}
+	if len(seats) < rules.MIN_PLAYERS || len(seats) > rules.MAX_PLAYERS {
+		return nil, errors.Errorf("games are for %d to %d seats, not %d", rules.MIN_PLAYERS, rules.MAX_PLAYERS, len(seats))
+	}
+	return seats, nil
+}
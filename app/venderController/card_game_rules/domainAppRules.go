@@ -13,6 +13,8 @@ package card_game_rules
 import (
 	"github.com/gopxl/pixel"
 	"github.com/quartermeat/card_game/assets"
+	"github.com/quartermeat/card_game/objects/venderModel/card"
+	"github.com/quartermeat/card_game/rules"
 )
 
 // domain specific constants
@@ -57,6 +59,8 @@ const (
 	BULLETS_DESC    = "bullets"
 	BULLETS_IMAGE   = "assets/images/zombieCards/15xBullets.png"
 	BULLETS_META    = "assets/images/zombieCards/bullets.csv"
+	// cost, types and effects of every card
+	CARD_DEFINITIONS = "assets/cards/cardDefinitions.csv"
 )
 
 var cardTypesMap = make(map[string]string)
@@ -105,3 +109,25 @@ func LoadAssets(sysErrors []error) assets.ObjectAssets {
 
 	return objectAssets
 }
+
+// LoadCardDefinitions loads the card definitions and checks them against the card art,
+// it has to run after LoadAssets has read the sheets
+func LoadCardDefinitions() (rules.CardDefinitions, error) {
+	definitions, err := rules.LoadCardDefinitions(CARD_DEFINITIONS)
+	if err != nil {
+		return nil, err
+	}
+
+	// the card back and the trash mat are in the sheets but aren't cards
+	artNames := make([]string, 0, len(cardTypesMap))
+	for name := range cardTypesMap {
+		if name != card.CARD_BACK && name != card.TRASH {
+			artNames = append(artNames, name)
+		}
+	}
+
+	if err := definitions.CheckArt(artNames); err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
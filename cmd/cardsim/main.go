@@ -0,0 +1,38 @@
+// cardsim runs the headless commands, like `cardsim simulate -games 100` from the repository root.
+// It only imports the rules engine and the packages built on it, so it builds without cgo or a display.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/quartermeat/card_game/rlenv"
+	"github.com/quartermeat/card_game/simulate"
+)
+
+var commands = map[string]func(args []string, stdout io.Writer) error{
+	"simulate":   simulate.Main,
+	"report":     simulate.ReportMain,
+	"tournament": simulate.TournamentMain,
+	"tune":       simulate.TuneMain,
+	"env":        rlenv.Main,
+}
+
+func main() {
+	if len(os.Args) < 2 || commands[os.Args[1]] == nil {
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(os.Stderr, "usage: cardsim <%s> [flags]\n", strings.Join(names, "|"))
+		os.Exit(2)
+	}
+	if err := commands[os.Args[1]](os.Args[2:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -5,17 +5,53 @@ type State int
 const (
 	Init State = iota
 	Ready
+	// PlayerTurn is the turn of a seat played by a person, AiTurn of a seat played by the AI
 	PlayerTurn
 	AiTurn
+	GameOver
+)
+
+// Phase is the part of a turn the current player is in
+type Phase int
+
+const (
+	ActionPhase Phase = iota
+	BuyPhase
+	CleanupPhase
+)
+
+func (phase Phase) String() string {
+	switch phase {
+	case ActionPhase:
+		return "Action"
+	case BuyPhase:
+		return "Buy"
+	case CleanupPhase:
+		return "Cleanup"
+	}
+	return "Unknown"
+}
+
+// every turn starts with one action and one buy
+const (
+	startingActions = 1
+	startingBuys    = 1
 )
 
 type StateManager struct {
 	currentState State
+	seats        []State // the turn state of each seat, in turn order
+	seat         int
+	phase        Phase
+	actions      int
+	buys         int
+	coins        int
 }
 
 func NewStateManager() *StateManager {
 	return &StateManager{
 		currentState: Init,
+		seats:        []State{PlayerTurn, AiTurn},
 	}
 }
 
@@ -26,3 +62,108 @@ func (sm *StateManager) GetCurrentState() State {
 func (sm *StateManager) SetCurrentState(newState State) {
 	sm.currentState = newState
 }
+
+// SetSeats sets who plays each seat in turn order, PlayerTurn for a person and AiTurn for the AI.
+// A new state manager has a person in the first seat and the AI in the second.
+func (sm *StateManager) SetSeats(seats ...State) {
+	sm.seats = append([]State(nil), seats...)
+}
+
+// GetSeats returns the number of seats
+func (sm *StateManager) GetSeats() int {
+	return len(sm.seats)
+}
+
+// GetSeat returns the seat taking its turn
+func (sm *StateManager) GetSeat() int {
+	return sm.seat
+}
+
+// StartTurn hands the turn to a seat and resets the phase and counters for a new turn
+func (sm *StateManager) StartTurn(seat int) {
+	sm.seat = seat
+	sm.currentState = sm.seats[seat]
+	sm.phase = ActionPhase
+	sm.actions = startingActions
+	sm.buys = startingBuys
+	sm.coins = 0
+}
+
+// IsTurn is true while a person or the AI is taking a turn
+func (sm *StateManager) IsTurn() bool {
+	return sm.currentState == PlayerTurn || sm.currentState == AiTurn
+}
+
+// EndPhase moves the turn on to its next phase, ending cleanup starts the next seat's turn.
+// It returns the phase the turn is now in.
+func (sm *StateManager) EndPhase() Phase {
+	if !sm.IsTurn() {
+		return sm.phase
+	}
+	switch sm.phase {
+	case ActionPhase:
+		sm.phase = BuyPhase
+		sm.actions = 0
+	case BuyPhase:
+		sm.phase = CleanupPhase
+		sm.buys = 0
+		sm.coins = 0
+	case CleanupPhase:
+		sm.StartTurn((sm.seat + 1) % len(sm.seats))
+	}
+	return sm.phase
+}
+
+func (sm *StateManager) GetPhase() Phase {
+	return sm.phase
+}
+
+func (sm *StateManager) GetActions() int {
+	return sm.actions
+}
+
+func (sm *StateManager) GetBuys() int {
+	return sm.buys
+}
+
+func (sm *StateManager) GetCoins() int {
+	return sm.coins
+}
+
+func (sm *StateManager) AddActions(actions int) {
+	sm.actions += actions
+}
+
+func (sm *StateManager) AddBuys(buys int) {
+	sm.buys += buys
+}
+
+func (sm *StateManager) AddCoins(coins int) {
+	sm.coins += coins
+}
+
+// UseAction spends an action, false if it isn't the action phase or none are left
+func (sm *StateManager) UseAction() bool {
+	if sm.phase != ActionPhase || sm.actions == 0 {
+		return false
+	}
+	sm.actions--
+	return true
+}
+
+// UseBuy spends a buy and cost coins, false if it isn't the buy phase or they can't be afforded
+func (sm *StateManager) UseBuy(cost int) bool {
+	if sm.phase != BuyPhase || sm.buys == 0 || sm.coins < cost {
+		return false
+	}
+	sm.buys--
+	sm.coins -= cost
+	return true
+}
+
+// Clone returns a copy of the state manager that can move on without changing the original
+func (sm *StateManager) Clone() *StateManager {
+	clone := *sm
+	clone.seats = append([]State(nil), sm.seats...)
+	return &clone
+}
@@ -0,0 +1,84 @@
+package gamestates
+
+import "testing"
+
+func TestEndPhaseCyclesTurn(t *testing.T) {
+	sm := NewStateManager()
+	sm.StartTurn(0)
+
+	if sm.GetPhase() != ActionPhase || sm.GetActions() != 1 || sm.GetBuys() != 1 || sm.GetCoins() != 0 {
+		t.Fatalf("unexpected start of turn: phase %s actions %d buys %d coins %d", sm.GetPhase(), sm.GetActions(), sm.GetBuys(), sm.GetCoins())
+	}
+	if sm.EndPhase() != BuyPhase {
+		t.Fatalf("expected buy phase, got %s", sm.GetPhase())
+	}
+	if sm.EndPhase() != CleanupPhase {
+		t.Fatalf("expected cleanup phase, got %s", sm.GetPhase())
+	}
+	if sm.EndPhase() != ActionPhase || sm.GetCurrentState() != AiTurn {
+		t.Fatalf("expected the AI's action phase, got %s in state %d", sm.GetPhase(), sm.GetCurrentState())
+	}
+}
+
+func TestCountersOnlySpendInTheirPhase(t *testing.T) {
+	sm := NewStateManager()
+	sm.StartTurn(0)
+
+	if sm.UseBuy(0) {
+		t.Error("bought during the action phase")
+	}
+	if !sm.UseAction() || sm.UseAction() {
+		t.Error("expected exactly one action")
+	}
+	sm.EndPhase()
+	sm.AddCoins(3)
+	if sm.UseBuy(4) {
+		t.Error("bought a card that costs more than the coins available")
+	}
+	if !sm.UseBuy(3) || sm.GetCoins() != 0 || sm.GetBuys() != 0 {
+		t.Errorf("expected buy to spend coins and buys, coins %d buys %d", sm.GetCoins(), sm.GetBuys())
+	}
+}
+
+func TestEndPhaseOutsideTurn(t *testing.T) {
+	sm := NewStateManager()
+	if sm.EndPhase() != ActionPhase || sm.GetCurrentState() != Init {
+		t.Error("ending a phase outside of a turn should do nothing")
+	}
+}
+
+func TestTurnsRotateAcrossSeats(t *testing.T) {
+	sm := NewStateManager()
+	sm.SetSeats(PlayerTurn, AiTurn, PlayerTurn, AiTurn)
+	sm.StartTurn(2)
+
+	expected := []State{AiTurn, PlayerTurn, AiTurn, PlayerTurn}
+	for turn, state := range expected {
+		sm.EndPhase()
+		sm.EndPhase()
+		sm.EndPhase()
+		seat := (3 + turn) % 4
+		if sm.GetSeat() != seat || sm.GetCurrentState() != state {
+			t.Fatalf("expected seat %d in state %d, got seat %d in state %d", seat, state, sm.GetSeat(), sm.GetCurrentState())
+		}
+	}
+	if sm.GetSeats() != 4 {
+		t.Errorf("expected 4 seats, got %d", sm.GetSeats())
+	}
+}
+
+func TestClone(t *testing.T) {
+	sm := NewStateManager()
+	sm.StartTurn(0)
+	clone := sm.Clone()
+	clone.SetSeats(AiTurn, AiTurn, AiTurn)
+	clone.EndPhase()
+	clone.AddCoins(3)
+
+	if sm.GetPhase() != ActionPhase || sm.GetCoins() != 0 || sm.GetSeats() != 2 {
+		t.Error("expected changes to the clone to leave the original alone")
+	}
+	if clone.GetPhase() != BuyPhase || clone.GetCoins() != 3 {
+		t.Error("expected the clone to move on by itself")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/quartermeat/card_game/assets"
 	"github.com/quartermeat/card_game/objects"
 	"github.com/quartermeat/card_game/objects/venderModel/card"
+	"github.com/quartermeat/card_game/rules"
 )
 
 // Commands is the map of commands to execute
@@ -47,7 +48,11 @@ func (command *addObjectAtPositionCommand) execute(waitGroup *sync.WaitGroup) {
 		{
 			*command.gameObjs = command.gameObjs.AppendGameObject(command.objectToPlace)
 		}
-	case card.IDeck:
+	case card.IPile:
+		{
+			*command.gameObjs = command.gameObjs.AppendGameObject(command.objectToPlace)
+		}
+	case objects.IOverlay:
 		{
 			*command.gameObjs = command.gameObjs.AppendGameObject(command.objectToPlace)
 		}
@@ -122,16 +127,31 @@ func (command *selectObjectAtPositionCommand) execute(waitGroup *sync.WaitGroup)
 	case Deck:
 		{
 			fmt.Printf("selected deck: %s\n", selectedObject.ObjectName())
-			selectedObject.GetFSM().SendEvent(Pull, selectedObject)
+			selectedObject.GetFSM().SendEvent(Buy, selectedObject)
 		}
 	case PlayerDeck:
 		{
+			// the deck is only drawn from by the rules engine
 			fmt.Printf("selected player deck: %s\n", selectedObject.ObjectName())
-			selectedObject.GetFSM().SendEvent(Pull, selectedObject)
 		}
 	case PlayerHand:
 		{
 			fmt.Printf("selected player hand: %s\n", selectedObject.ObjectName())
+			if selectedObject.(*card.Hand).Select(command.position) {
+				selectedObject.GetFSM().SendEvent(Play, selectedObject)
+			}
+		}
+	case DecisionOverlay:
+		{
+			fmt.Printf("selected decision: %s\n", selectedObject.ObjectName())
+			if selectedObject.(*card.DecisionOverlay).Select(command.position) {
+				selectedObject.GetFSM().SendEvent(Choose, selectedObject)
+			}
+		}
+	case Trash:
+		{
+			fmt.Printf("selected trash: %s\n", selectedObject.ObjectName())
+			selectedObject.GetFSM().SendEvent(Browse, selectedObject)
 		}
 	}
 
@@ -172,3 +192,49 @@ func MoveSelectedToPositionObject(objs *objects.GameObjects, fromPosition pixel.
 		position: fromPosition,
 	}
 }
+
+type endPhaseCommand struct {
+	game *rules.GameState
+}
+
+func (command *endPhaseCommand) GetPositionOfOjbectCommand() pixel.Vec{
+	return pixel.ZV
+}
+
+func (command *endPhaseCommand) execute(waitGroup *sync.WaitGroup) {
+	phase, err := command.game.EndPhase()
+	if err != nil {
+		fmt.Printf("can't end phase: %s\n", err)
+	}
+	fmt.Printf("phase: %s\n", phase)
+	waitGroup.Done()
+}
+
+// EndPhase moves the current turn on to its next phase
+func EndPhase(game *rules.GameState) ICommand {
+	return &endPhaseCommand{
+		game: game,
+	}
+}
+
+type playTreasuresCommand struct {
+	game *rules.GameState
+}
+
+func (command *playTreasuresCommand) GetPositionOfOjbectCommand() pixel.Vec{
+	return pixel.ZV
+}
+
+func (command *playTreasuresCommand) execute(waitGroup *sync.WaitGroup) {
+	if err := command.game.PlayAllTreasures(command.game.CurrentPlayer()); err != nil {
+		fmt.Printf("can't play treasures: %s\n", err)
+	}
+	waitGroup.Done()
+}
+
+// PlayTreasures plays every treasure in the current player's hand
+func PlayTreasures(game *rules.GameState) ICommand {
+	return &playTreasuresCommand{
+		game: game,
+	}
+}
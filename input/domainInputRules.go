@@ -2,13 +2,13 @@ package input
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/gopxl/pixel"
 	"github.com/gopxl/pixel/pixelgl"
 	"github.com/quartermeat/card_game/assets"
 	"github.com/quartermeat/card_game/objects"
 	"github.com/quartermeat/card_game/objects/venderModel/card"
+	"github.com/quartermeat/card_game/rules"
 )
 
 const (
@@ -20,241 +20,130 @@ var (
 	Card = "Card"
 	Deck = "Deck"
 	PlayerDeck = "PlayerDeck"
-	PlayerHand = "PlayerHand"
+	PlayerHand = "Hand"
+	Trash = "Trash"
+	DecisionOverlay = "DecisionOverlay"
 )
 
 var (
 	// Object Events
 	Flip = card.Flip
-	Pull = card.Pull
+	Play = card.Play
+	Buy  = card.Buy
+	Browse = card.Browse
+	Choose = card.Choose
 )
 
-// seems I can't stack commands, so InitGame has to happen in stages
+// seems I can't stack commands, so InitGame has to happen in stages, placing one object of the table each stage
 var (
 	CommandStage = 0
-	kingdom_card_bag = []string{"ham_radio", 
-								"survivors",
-								"1_in_the_chamber",
-								"ammo_box",
-								"barricade",
-								"courage",
-								"cunning",
-								"decoy",
-								"hide",
-								"higher_ground",
-								"hollow_points",
-								"maverick",
-								"molotov_cocktail",
-								"quick_escape",
-								"recon",
-								"regroup",
-								"reload",
-								"restock",
-								"sacrifice",
-								"scavenger",
-								"shotgun",
-								"sidekick",
-								"stick_together",
-								"zombie_swarm",
-								"tactics",
-								"weapons_cache",
-								}
+	table        []placement
 )
 
-func getRandomKingdomCard() string {
-	index := rand.Intn(len(kingdom_card_bag))
-	card := kingdom_card_bag[index]
-	kingdom_card_bag = append(kingdom_card_bag[:index], kingdom_card_bag[index+1:]...)	
-	return card
+// seatAnchors is where each seat's deck goes, the rest of the seat is laid out from it
+var seatAnchors = []pixel.Vec{{X: -400, Y: -300}, {X: 2000, Y: -300}, {X: -400, Y: 1900}, {X: 2000, Y: 1900}}
+
+// placement is an object of the table and where it goes
+type placement struct {
+	object   objects.IGameObject
+	location pixel.Vec
 }
 
 // setup game board, create objects, and setup input
-func InitGame(win *pixelgl.Window, cam *pixel.Matrix, gameCommands Commands, gameObjs *objects.GameObjects, objectAssets assets.ObjectAssets) bool {
-	
+func InitGame(win *pixelgl.Window, cam *pixel.Matrix, gameCommands Commands, gameObjs *objects.GameObjects, objectAssets assets.ObjectAssets, game *rules.GameState) bool {
+	if CommandStage == 0 {
+		var err error
+		if table, err = layoutTable(objectAssets, game); err != nil {
+			panic(err)
+		}
+	}
+	if CommandStage >= len(table) {
+		fmt.Printf("InitGame: CommandStage %d is not defined\n", CommandStage)
+		return true
+	}
+
+	objectToPlace := table[CommandStage]
+	gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", objectToPlace.location.X, objectToPlace.location.Y, objectToPlace.object.ObjectName())] = AddObjectAtPosition(gameObjs, objectToPlace.object, objectToPlace.location)
+	CommandStage++
+	return CommandStage == len(table)
+}
+
+// layoutTable sets up the supply for the players already seated, and lays out an object for it and for each seat
+func layoutTable(objectAssets assets.ObjectAssets, game *rules.GameState) ([]placement, error) {
+	if len(game.Players) > len(seatAnchors) {
+		return nil, fmt.Errorf("no room at the table for %d seats", len(game.Players))
+	}
+	if err := game.SetupSupply(rules.DefaultSetup, len(game.Players)); err != nil {
+		return nil, err
+	}
+	// a new game's cards get new views, the last game's aren't drawn again
+	card.ClearCardViews()
+
 	// setup a deck of cards positioned on the wooden background
-	// start with a top row of 6 decks, treaure and estates
+	// the top row has the treasures, then the victory cards and infections, with the trash at the end
+	// the kingdom is the row under it
 	//top left corner: x:-900, y:1200
 	startx := -900.0
 	starty := 1100.0
 	rowy := 700.0
-	var objectToPlace objects.IGameObject
-	victory_point_deck_size := 8
-	kingdom_card_deck_size := 8
-
-	switch(CommandStage){
-	case 0:{
-		//first deck is the bullet deck
-		bullet_deck_size := 80
-		location := pixel.Vec{X: startx, Y: starty}
-		objectToPlace := card.NewDeckObject(objectAssets, bullet_deck_size, "bullet", location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)
-		CommandStage = 1
-		return false
-	}
-	case 1:{
-		// to the right of the bullet deck is the slug deck
-		slug_deck_size := 70
-		location := pixel.Vec{X: startx + 250, Y: starty}
-		objectToPlace := card.NewDeckObject(objectAssets, slug_deck_size, "slug", location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 2
-		return false
-	}
-	case 2:{
-		// to the right of the slug deck is the shell deck
-		shell_deck_size := 48
-		location := pixel.Vec{X: startx + 500, Y: starty}
-		objectToPlace := card.NewDeckObject(objectAssets, shell_deck_size, "shells", location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 3
-		return false
-	}
-	case 3:{
-		// to the right of the shell deck is the start of the 'estate' cards, first with zombies
-		location := pixel.Vec{X: startx + 800, Y: starty}
-		objectToPlace := card.NewDeckObject(objectAssets, victory_point_deck_size, "zombies", location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 4
-		return false
-	}
-	case 4:{
-		// to the right of the zombie deck is the more_zombies deck
-		location := pixel.Vec{X: startx + 1050, Y: starty}
-		objectToPlace := card.NewDeckObject(objectAssets, victory_point_deck_size, "more_zombies", location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 5
-		return false
-	}
-	case 5:{
-		// to the right of the more_zombies deck is the even_more_zombies deck
-		location := pixel.Vec{X: startx + 1300, Y: starty}
-		objectToPlace := card.NewDeckObject(objectAssets, victory_point_deck_size, "even_more_zombies", location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 6
-		return false
-	}
-	case 6:{
-		// to the right of the even_more_zombies deck is the infections deck
-		infections_deck_size := 10
-		location := pixel.Vec{X: startx + 1550, Y: starty}
-		objectToPlace := card.NewDeckObject(objectAssets, infections_deck_size, "infection", location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 7
-		return false
-	}
-	case 7:{
-		// Kingdom card 1
-		
-		location := pixel.Vec{X: startx, Y: rowy}
-		objectToPlace := card.NewDeckObject(objectAssets, kingdom_card_deck_size, getRandomKingdomCard(), location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 8
-		return false
+	spacing := 250.0
+	victoryx := startx + 800
+	table := make([]placement, 0)
+	place := func(object objects.IGameObject, location pixel.Vec) {
+		table = append(table, placement{object, location})
 	}
-	case 8:{
-		// Kingdom card 2
-		location := pixel.Vec{X: startx + 250, Y: rowy}
-		objectToPlace := card.NewDeckObject(objectAssets, kingdom_card_deck_size, getRandomKingdomCard(), location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 9
-		return false
-	}
-	case 9:{
-		// Kingdom card 3
-		location := pixel.Vec{X: startx + 500, Y: rowy}
-		objectToPlace := card.NewDeckObject(objectAssets, kingdom_card_deck_size, getRandomKingdomCard(), location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 10
-		return false
-	}
-	case 10:{
-		// Kingdom card 4
-		location := pixel.Vec{X: startx + 750, Y: rowy}
-		objectToPlace := card.NewDeckObject(objectAssets, kingdom_card_deck_size, getRandomKingdomCard(), location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 11
-		return false
-	}
-	case 11:{
-		// Kingdom card 5
-		location := pixel.Vec{X: startx + 1000, Y: rowy}
-		objectToPlace := card.NewDeckObject(objectAssets, kingdom_card_deck_size, getRandomKingdomCard(), location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 12
-		return false
-	}
-	case 12:{
-		// Kingdom card 6
-		location := pixel.Vec{X: startx + 1250, Y: rowy}
-		objectToPlace := card.NewDeckObject(objectAssets, kingdom_card_deck_size, getRandomKingdomCard(), location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 13
-		return false
-	}
-	case 13:{
-		// Kingdom card 7
-		location := pixel.Vec{X: startx + 1500, Y: rowy}
-		objectToPlace := card.NewDeckObject(objectAssets, kingdom_card_deck_size, getRandomKingdomCard(), location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 14
-		return false
-	}
-	case 14:{
-		// Kingdom card 8
-		location := pixel.Vec{X: startx + 1750, Y: rowy}
-		objectToPlace := card.NewDeckObject(objectAssets, kingdom_card_deck_size, getRandomKingdomCard(), location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 15
-		return false
-	}
-	case 15:{
-		// Kingdom card 9
-		location := pixel.Vec{X: startx + 2000, Y: rowy}
-		objectToPlace := card.NewDeckObject(objectAssets, kingdom_card_deck_size, getRandomKingdomCard(), location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 16
-		return false
-	}
-	case 16:{
-		// Kingdom card 10
-		location := pixel.Vec{X: startx + 2250, Y: rowy}
-		objectToPlace := card.NewDeckObject(objectAssets, kingdom_card_deck_size, getRandomKingdomCard(), location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 17
-		return false
-	}
-	case 17:{
-		// Player Deck setup
+
+	treasures := len(rules.DefaultSetup.Treasures)
+	victory := treasures + len(rules.DefaultSetup.Victory) + 1
+	for index, pile := range game.Supply {
+		var location pixel.Vec
+		switch {
+		case index < treasures:
+			location = pixel.Vec{X: startx + float64(index)*spacing, Y: starty}
+		case index < victory:
+			location = pixel.Vec{X: victoryx + float64(index-treasures)*spacing, Y: starty}
+		default:
+			location = pixel.Vec{X: startx + float64(index-victory)*spacing, Y: rowy}
+		}
+		deck := card.NewDeckObject(objectAssets, game, pile, location)
+		place(&deck, location)
+	}
+	trashLocation := pixel.Vec{X: victoryx + float64(victory-treasures)*spacing, Y: starty}
+	trash := card.NewTrashObject(objectAssets, game, trashLocation)
+	place(&trash, trashLocation)
+
+	// every seat gets its deck, discard pile, hand and cards in play laid out around the supply,
+	// the first two seats under it and the others above it
+	for seat, player := range game.Players {
+		anchor := seatAnchors[seat]
 		// executing: SelectObjectAtPosition x:-394.317658, y:-295.212168
-		location := pixel.Vec{X: -400, Y: -300}
-		objectToPlace := card.NewPlayerDeckObject(objectAssets, location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 18
-		return false
-	}
-	case 18:{
-		// AI Deck setup
-		location := pixel.Vec{X: 2000, Y: -300}
-		objectToPlace := card.NewPlayerDeckObject(objectAssets, location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)	
-		CommandStage = 19
-		return false
-	}
-	case 19:{
-		// Player Hand setup
-		location := pixel.Vec{X: 700, Y: -300}
-		objectToPlace := card.NewHandObject(objectAssets, location)
-		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", location.X, location.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, location)
-				
-		CommandStage = 20
-		return true
-	}
-	default:{
-		fmt.Printf("InitGame: CommandStage %d is not defined, objectToPlace: %s", CommandStage, objectToPlace.ObjectName())
+		deckLocation := anchor
+		deck := card.NewPlayerDeckObject(objectAssets, player, deckLocation)
+		place(&deck, deckLocation)
+		discardLocation := anchor.Add(pixel.V(250, 0))
+		discard := card.NewDiscardPileObject(objectAssets, player.Discard, discardLocation)
+		place(&discard, discardLocation)
+		// the opening hand is dealt from the deck when the game starts
+		handLocation := anchor.Add(pixel.V(1100, 0))
+		hand := card.NewHandObject(objectAssets, game, player, handLocation)
+		place(&hand, handLocation)
+		// cards played are laid out in a row between the seat and the supply
+		inPlayLocation := anchor.Add(pixel.V(0, 400))
+		if anchor.Y > starty {
+			inPlayLocation = anchor.Sub(pixel.V(0, 400))
+		}
+		inPlay := card.NewInPlayObject(objectAssets, player, inPlayLocation)
+		place(&inPlay, inPlayLocation)
 	}
+
+	// decisions a person has to make are asked over the middle of the table
+	overlayLocation := pixel.Vec{X: 500, Y: 500}
+	for seat, player := range game.Players {
+		if player.Human {
+			overlay := card.NewDecisionOverlayObject(objectAssets, game, seat, overlayLocation)
+			place(&overlay, overlayLocation)
+		}
 	}
 
-	// arrange the kingdom in the middle
-	
-	return false
-}
\ No newline at end of file
+	return table, nil
+}
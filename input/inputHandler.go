@@ -9,8 +9,10 @@ import (
 	"github.com/quartermeat/card_game/assets"
 	"github.com/quartermeat/card_game/console"
 	"github.com/quartermeat/card_game/debuglog"
+	"github.com/quartermeat/card_game/gamestates"
 	"github.com/quartermeat/card_game/objects"
 	"github.com/quartermeat/card_game/objects/venderModel/card"
+	"github.com/quartermeat/card_game/rules"
 	"golang.org/x/exp/slices"
 )
 
@@ -80,6 +82,8 @@ func (input *InputHandler) HandleInput(
 	drawHitBox *bool,
 	readConsole <-chan console.ITxTopic,
 	debugLog debuglog.Entries,
+	game *rules.GameState,
+	stateManager *gamestates.StateManager,
 ) (debuglog.Entries, error) {	//defaults
 	var (
 		cursorToggle bool
@@ -107,7 +111,7 @@ func (input *InputHandler) HandleInput(
 			}
 			debugLog = append(debugLog, indexError)
 		}
-		input.initialized = InitGame(win, cam, gameCommands, gameObjs, objectAssets)
+		input.initialized = InitGame(win, cam, gameCommands, gameObjs, objectAssets, game)
 		return debugLog, nil
 	}
 
@@ -154,7 +158,11 @@ func (input *InputHandler) HandleInput(
 
 	if win.JustPressed(pixelgl.Key0) {
 		mouse := cam.Unproject(win.MousePosition())
-		objectToPlace := card.NewDeckObject(objectAssets, 10, "zombies", mouse)
+		pile := rules.NewPile("zombies")
+		for i := 0; i < 10; i++ {
+			pile.Push(game.NewCard("zombies"))
+		}
+		objectToPlace := card.NewDeckObject(objectAssets, game, pile, mouse)
 		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", mouse.X, mouse.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, mouse)
 	}
 
@@ -166,10 +174,24 @@ func (input *InputHandler) HandleInput(
 
 	if win.JustPressed(pixelgl.Key1){
 		mouse := cam.Unproject(win.MousePosition())
-		objectToPlace := card.NewHandObject(objectAssets, mouse)
+		pile := rules.NewPile("zombies")
+		for i := 0; i < 5; i++ {
+			pile.Push(game.NewCard("zombies"))
+		}
+		objectToPlace := card.NewHandObject(objectAssets, game, &rules.Player{Name: "debug", Hand: pile, Human: true}, mouse)
 		gameCommands[fmt.Sprintf("AddObjectAtPosition: x:%f, y:%f, ObjectType:%s", mouse.X, mouse.Y, objectToPlace.ObjectName())] = AddObjectAtPosition(gameObjs, &objectToPlace, mouse)
 	}
 
+	//end the current phase of the player's turn
+	if win.JustPressed(pixelgl.KeySpace) && stateManager.GetCurrentState() == gamestates.PlayerTurn {
+		gameCommands[fmt.Sprintf("EndPhase: %s", stateManager.GetPhase())] = EndPhase(game)
+	}
+
+	//play every treasure in the player's hand
+	if win.JustPressed(pixelgl.KeyT) && stateManager.GetCurrentState() == gamestates.PlayerTurn {
+		gameCommands["PlayTreasures"] = PlayTreasures(game)
+	}
+
 	//toggle global hit box draw for debugging
 	if win.JustPressed(pixelgl.KeyH) {
 		*drawHitBox = !*drawHitBox
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	_ "image/png"
 
 	"github.com/gopxl/pixel/pixelgl"
@@ -9,6 +10,7 @@ import (
 
 // utilizes the Pixel library for 2D game development and a custom package for the card game logic. The main function calls the pixelgl.Run function with app.AppRun as an argument, which will run the card game in an OpenGL-backed window with input handling.
 func main() {
+	flag.Parse()
 	pixelgl.Run(app.AppRun)
 	// scratch.RunDalleTest()
 }
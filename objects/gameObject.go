@@ -34,6 +34,17 @@ type IGameObject interface {
 	MoveToPosition(position pixel.Vec)
 }
 
+// IOverlay is a game object drawn over all the others and selected before them, like a prompt.
+type IOverlay interface {
+	IGameObject
+	IsOverlay() bool
+}
+
+func isOverlay(object IGameObject) bool {
+	overlay, ok := object.(IOverlay)
+	return ok && overlay.IsOverlay()
+}
+
 // GameObjects is a slice of all game objects.
 type GameObjects []IGameObject
 
@@ -62,11 +73,22 @@ func (gameObjs GameObjects) UpdateAllObjects(dt float64, waitGroup *sync.WaitGro
 }
 
 // DrawAllObjects runs the Draw method for all game objects in their own goroutine.
+// Overlays are drawn once everything else has been drawn, so they end up on top.
 func (gameObjs GameObjects) DrawAllObjects(win *pixelgl.Window, drawHitBox bool, waitGroup *sync.WaitGroup, app *observable.ObservableState) {
 	for _, obj := range gameObjs {
+		if isOverlay(obj) {
+			continue
+		}
 		waitGroup.Add(1)
 		go obj.Draw(win, drawHitBox, waitGroup)
 	}
+	waitGroup.Wait()
+	for _, obj := range gameObjs {
+		if isOverlay(obj) {
+			waitGroup.Add(1)
+			obj.Draw(win, drawHitBox, waitGroup)
+		}
+	}
 }
 
 // GetSelectedGameObjAtPosition checks if a mouse click intersects with a game object's hitbox.
@@ -79,6 +101,12 @@ func (gameObjs GameObjects) GetSelectedGameObjAtPosition(position pixel.Vec) (IG
 	if len(gameObjs) == 0 {
 		return nil, noIndex, foundObject, errors.New("getSelectedGameObj: no game object exists")
 	}
+	// overlays are on top, so they get the first chance to be selected
+	for index, object := range gameObjs {
+		if isOverlay(object) && object.Selectable() && object.GetHitBox().Contains(position) {
+			return object, index, true, nil
+		}
+	}
 	for index, object := range gameObjs {
 		hit := object.GetHitBox().Contains(position)
 		selectable := object.Selectable()
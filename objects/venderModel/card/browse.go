@@ -0,0 +1,19 @@
+package card
+
+import (
+	"fmt"
+
+	"github.com/quartermeat/card_game/objects"
+)
+
+// BrowseAction represents opening or closing the view of everything in the trash.
+type BrowseAction struct{}
+
+// Execute toggles showing the trash's contents.
+func (ba *BrowseAction) Execute(gameObj objects.IGameObject) objects.EventType {
+	trash := gameObj.(*Trash)
+	trash.browsing = !trash.browsing
+	fmt.Printf("browsing trash: %t, %d cards\n", trash.browsing, trash.pile.Len())
+
+	return objects.NoOp
+}
@@ -0,0 +1,27 @@
+package card
+
+import (
+	"fmt"
+
+	"github.com/quartermeat/card_game/gamestates"
+	"github.com/quartermeat/card_game/objects"
+)
+
+// BuyAction represents buying the top card of a supply deck.
+type BuyAction struct{}
+
+// Execute buys the top card of the deck for the player at the table.
+func (ba *BuyAction) Execute(gameObj objects.IGameObject) objects.EventType{
+	deck := gameObj.(*Deck)
+	// only the player at the table buys by clicking, the AI buys on its own turn
+	if deck.game.StateManager.GetCurrentState() != gamestates.PlayerTurn {
+		return objects.NoOp
+	}
+
+	fmt.Printf("Buying %s\n", deck.pile.Name)
+	if err := deck.game.Buy(deck.game.CurrentPlayer(), deck.pile); err != nil {
+		fmt.Printf("can't buy %s: %s\n", deck.pile.Name, err)
+	}
+
+	return objects.NoOp
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gopxl/pixel"
 	"github.com/gopxl/pixel/pixelgl"
 	"github.com/quartermeat/card_game/objects"
+	"github.com/quartermeat/card_game/rules"
 )
 
 const (
@@ -13,7 +14,7 @@ const (
 	HAM_RADIO         = "ham_radio"
 	TRASH             = "trash"
 	SLUG              = "slug"
-	SURVIVOR          = "survivor"
+	SURVIVORS         = "survivors"
 	ZOMBIES           = "zombies"
 	MORE_ZOMBIES      = "more_zombies"
 	EVEN_MORE_ZOMBIES = "even_more_zombies"
@@ -41,6 +42,9 @@ const (
 	ZOMBIE_SWARM      = "zombie_swarm"
 	TACTICS           = "tactics"
 	WEAPONS_CACHE     = "weapons_cache"
+	IN_THE_CHAMBER    = "1_in_the_chamber"
+	SHELLS            = "shells"
+	INFECTION         = "infection"
 	CARD_BACK         = "card_back"
 )
 
@@ -49,10 +53,12 @@ type ICard interface {
 	GetPosition() pixel.Vec
 	Sprite() *pixel.Sprite
 	SetMatrix(matrix pixel.Matrix)
+	Model() *rules.Card
 }
 
-type IDeck interface {
-	PullCard() ICard
+// IPile is a view of a pile of cards in the rules model
+type IPile interface {
+	GetPile() *rules.Pile
 }
 
 type IHand interface{
@@ -68,6 +74,8 @@ const (
 	Empty objects.StateType = "Empty"
 	
 	Flip objects.EventType = "Flip"
-	Pull objects.EventType = "Pull"	
 	Play objects.EventType = "Play"
+	Buy  objects.EventType = "Buy"
+	Browse objects.EventType = "Browse"
+	Choose objects.EventType = "Choose"
 )
\ No newline at end of file
@@ -10,8 +10,16 @@ import (
 	"github.com/quartermeat/card_game/assets"
 	"github.com/quartermeat/card_game/objects"
 	"github.com/quartermeat/card_game/observable"
+	"github.com/quartermeat/card_game/rules"
 )
 
+// cardViews keeps one Card game object per rules.Card of the current game,
+// so a card keeps its sprites as it moves between zones
+var cardViews = struct {
+	sync.Mutex
+	cards map[*rules.Card]*Card
+}{cards: make(map[*rules.Card]*Card)}
+
 type Card struct {
 	stateMachine *objects.StateMachine
 	currentState objects.StateType
@@ -27,6 +35,7 @@ type Card struct {
 	position     pixel.Vec
 	matrix       pixel.Matrix
 	observable   *observable.Observable
+	model        *rules.Card
 }
 
 // ObjectName is the string identifier for the object
@@ -98,7 +107,9 @@ func (card *Card) GetPosition() pixel.Vec {
 }
 
 func (card *Card) MoveToPosition(position pixel.Vec) {
-	panic("not implemented") // TODO: Implement
+	card.position = position
+	card.matrix = pixel.IM.Moved(position)
+	card.SetHitBox()
 }
 
 func (card *Card) GetFSM() *objects.StateMachine {
@@ -142,6 +153,23 @@ func (card *Card) SetMatrix(matrix pixel.Matrix) {
 	card.matrix = matrix
 }
 
+// Contains checks if a position is on the card as it is drawn, taking its rotation into account
+func (card *Card) Contains(position pixel.Vec) bool {
+	local := card.matrix.Unproject(position)
+	frame := card.front_sprite.Frame()
+	return local.X >= -frame.W()/2 && local.X <= frame.W()/2 && local.Y >= -frame.H()/2 && local.Y <= frame.H()/2
+}
+
+// SetState sets how the card is shown, used by the zone the card is drawn in
+func (card *Card) SetState(state objects.StateType) {
+	card.currentState = state
+}
+
+// Model returns the rules card this object is a view of, nil for a card placed on its own
+func (card *Card) Model() *rules.Card {
+	return card.model
+}
+
 // NewCardObject creates a new card game object
 func NewCardObject(objectAssets assets.ObjectAssets, position pixel.Vec, card_name string, state objects.StateType) Card {
 	objectAsset := objectAssets.GetImage(card_name)
@@ -167,3 +195,37 @@ func NewCardObject(objectAssets assets.ObjectAssets, position pixel.Vec, card_na
 	objects.NextID++
 	return newCard
 }
+
+// ClearCardViews forgets the views of every card, called when the table is laid out for a new game
+func ClearCardViews() {
+	cardViews.Lock()
+	defer cardViews.Unlock()
+	cardViews.cards = make(map[*rules.Card]*Card)
+}
+
+// viewOf returns the Card game object drawn for a rules card, creating it the first time the card is seen
+func viewOf(objectAssets assets.ObjectAssets, model *rules.Card) *Card {
+	cardViews.Lock()
+	defer cardViews.Unlock()
+
+	view, ok := cardViews.cards[model]
+	if !ok {
+		newCard := NewCardObject(objectAssets, pixel.ZV, model.Name, Hidden)
+		newCard.model = model
+		view = &newCard
+		cardViews.cards[model] = view
+	}
+	return view
+}
+
+// cardSize returns the width and height of a card image
+func cardSize(objectAssets assets.ObjectAssets) (float64, float64) {
+	frame := objectAssets.GetImage(CARD_BACK).GetImage(CARD_BACK)
+	return frame.W(), frame.H()
+}
+
+// stackPosition offsets the cards of a pile so its height is visible, every 13 cards moves up and right
+func stackPosition(position pixel.Vec, index int) pixel.Vec {
+	offset := float64(2 * (index/13 + 1))
+	return position.Add(pixel.V(offset, offset))
+}
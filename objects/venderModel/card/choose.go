@@ -0,0 +1,39 @@
+package card
+
+import (
+	"fmt"
+
+	"github.com/quartermeat/card_game/objects"
+)
+
+// ChooseAction represents clicking in the decision overlay.
+type ChooseAction struct{}
+
+// Execute picks or unpicks the clicked option, or answers the decision when done is clicked.
+func (ca *ChooseAction) Execute(gameObj objects.IGameObject) objects.EventType {
+	overlay := gameObj.(*DecisionOverlay)
+	clicked := overlay.clicked
+	overlay.clicked = noClick
+	if overlay.decision == nil || overlay.decision != overlay.game.PendingDecision() {
+		return objects.NoOp
+	}
+
+	switch {
+	case clicked == doneClick:
+		if err := overlay.game.Answer(overlay.picks()); err != nil {
+			fmt.Printf("can't answer %s: %s\n", overlay.decision.Prompt, err)
+		}
+	case clicked >= 0:
+		if overlay.picked[clicked] {
+			delete(overlay.picked, clicked)
+			break
+		}
+		// with a single pick the new option takes the place of the old one
+		if overlay.decision.Max == 1 {
+			overlay.picked = make(map[int]bool)
+		}
+		overlay.picked[clicked] = true
+	}
+
+	return objects.NoOp
+}
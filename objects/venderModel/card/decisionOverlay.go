@@ -0,0 +1,309 @@
+package card
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"sync"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/imdraw"
+	"github.com/gopxl/pixel/pixelgl"
+	"github.com/gopxl/pixel/text"
+	"github.com/quartermeat/card_game/assets"
+	"github.com/quartermeat/card_game/objects"
+	"github.com/quartermeat/card_game/observable"
+	"github.com/quartermeat/card_game/rules"
+	"golang.org/x/image/colornames"
+)
+
+const (
+	// options per row of the overlay
+	overlayColumns = 8
+	// the overlay lays its cards out at this scale so a whole hand fits
+	overlayCardScale = 0.75
+	// noClick is the click target when nothing in the overlay was clicked
+	noClick = -1
+	// doneClick is the click target of the done button
+	doneClick = -2
+)
+
+// DecisionOverlay shows the decision the engine is waiting on from a seat at the table.
+// Options are selected and unselected by clicking them, and done answers with the selection.
+type DecisionOverlay struct {
+	game         *rules.GameState
+	seat         int
+	objectAssets assets.ObjectAssets
+	asset        assets.ObjectImageAsset
+	art          map[string]*pixel.Sprite
+	decision     *rules.Decision
+	picked       map[int]bool
+	clicked      int
+	optionBoxes  []pixel.Rect
+	doneBox      pixel.Rect
+	position     pixel.Vec
+	hitBox       pixel.Rect
+	observable   *observable.Observable
+	stateMachine *objects.StateMachine
+	currentState objects.StateType
+	id           int
+	sprite       *pixel.Sprite
+	height       float64
+	width        float64
+}
+
+// ObjectName is the string identifier for the object
+func (overlay *DecisionOverlay) ObjectName() string {
+	return "DecisionOverlay"
+}
+
+// IsOverlay keeps the overlay on top of the table
+func (overlay *DecisionOverlay) IsOverlay() bool {
+	return true
+}
+
+func (overlay *DecisionOverlay) GetFSM() *objects.StateMachine {
+	return overlay.stateMachine
+}
+
+func (overlay *DecisionOverlay) Sprite() *pixel.Sprite {
+	return overlay.sprite
+}
+
+func (overlay *DecisionOverlay) GetAssets() assets.IObjectAsset {
+	return overlay.asset
+}
+
+// Selectable is true while the seat has a decision to make
+func (overlay *DecisionOverlay) Selectable() bool {
+	return overlay.pending() != nil
+}
+
+func (overlay *DecisionOverlay) GetID() int {
+	return overlay.id
+}
+
+// pending returns the decision waiting on the overlay's seat, nil if there isn't one
+func (overlay *DecisionOverlay) pending() *rules.Decision {
+	decision := overlay.game.PendingDecision()
+	if decision == nil || decision.Player != overlay.seat {
+		return nil
+	}
+	return decision
+}
+
+// Update starts a fresh selection and lays out the options whenever a new decision comes up
+func (overlay *DecisionOverlay) Update(dt float64, gameObjects objects.GameObjects, waitGroup *sync.WaitGroup) {
+	decision := overlay.pending()
+	if decision != overlay.decision {
+		overlay.decision = decision
+		overlay.picked = make(map[int]bool)
+		overlay.clicked = noClick
+		overlay.layout()
+	}
+	waitGroup.Done()
+}
+
+// layout places a box for every option in rows under the prompt, with the done button after them
+func (overlay *DecisionOverlay) layout() {
+	overlay.optionBoxes = overlay.optionBoxes[:0]
+	if overlay.decision == nil {
+		overlay.hitBox = pixel.ZR
+		return
+	}
+	width, height := overlay.width*overlayCardScale, overlay.height*overlayCardScale
+	count := len(overlay.decision.Options) + 1
+	columns := count
+	if columns > overlayColumns {
+		columns = overlayColumns
+	}
+	rows := (count-1)/columns + 1
+	topLeft := overlay.position.Sub(pixel.V(float64(columns)*width/2, 0))
+
+	for index := 0; index < count; index++ {
+		min := topLeft.Add(pixel.V(float64(index%columns)*width, -float64(index/columns+1)*height))
+		box := pixel.R(min.X, min.Y, min.X+width, min.Y+height)
+		if index == count-1 {
+			// the done button is a bar at the bottom of its slot
+			overlay.doneBox = pixel.R(box.Min.X, box.Min.Y, box.Max.X, box.Min.Y+height/4)
+			break
+		}
+		overlay.optionBoxes = append(overlay.optionBoxes, box)
+	}
+	overlay.hitBox = pixel.R(topLeft.X, topLeft.Y-float64(rows)*height, topLeft.X+float64(columns)*width, topLeft.Y+height/2)
+}
+
+func (overlay *DecisionOverlay) Draw(win *pixelgl.Window, drawHitBox bool, waitGroup *sync.WaitGroup) {
+	decision := overlay.decision
+	if decision == nil {
+		waitGroup.Done()
+		return
+	}
+
+	imd := imdraw.New(nil)
+	imd.Color = pixel.RGBA{R: 0, G: 0, B: 0, A: 0.85}
+	imd.Push(overlay.hitBox.Min, overlay.hitBox.Max)
+	imd.Rectangle(0)
+	for index, box := range overlay.optionBoxes {
+		imd.Color = colornames.White
+		thickness := 2.0
+		if overlay.picked[index] {
+			imd.Color = colornames.Yellow
+			thickness = 8
+		}
+		imd.Push(box.Min, box.Max)
+		imd.Rectangle(thickness)
+	}
+	imd.Color = colornames.Darkgray
+	if overlay.canAnswer() {
+		imd.Color = colornames.Green
+	}
+	imd.Push(overlay.doneBox.Min, overlay.doneBox.Max)
+	imd.Rectangle(0)
+	imd.Draw(win)
+
+	for index, option := range decision.Options {
+		box := overlay.optionBoxes[index]
+		if sprite := overlay.artFor(option); sprite != nil {
+			sprite.Draw(win, pixel.IM.Scaled(pixel.ZV, overlayCardScale*0.9).Moved(box.Center()))
+		} else {
+			drawLabel(win, option.Name, box.Center(), colornames.White)
+		}
+	}
+	drawLabel(win, decision.Prompt, overlay.position.Add(pixel.V(0, overlay.height*overlayCardScale/4)), colornames.White)
+	drawLabel(win, fmt.Sprintf("done %d/%d", len(overlay.picked), decision.Max), overlay.doneBox.Center(), colornames.Black)
+
+	if drawHitBox {
+		hitBox := imdraw.New(nil)
+		hitBox.Color = pixel.RGB(0, 255, 0)
+		hitBox.Push(overlay.hitBox.Min, overlay.hitBox.Max)
+		hitBox.Rectangle(1)
+		hitBox.Draw(win)
+	}
+	waitGroup.Done()
+}
+
+// artFor returns the card art of an option, nil for options that aren't cards or piles
+func (overlay *DecisionOverlay) artFor(option rules.Option) *pixel.Sprite {
+	name := ""
+	switch {
+	case option.Card != nil:
+		name = option.Card.Name
+	case option.Pile != nil:
+		name = option.Pile.Name
+	default:
+		return nil
+	}
+	if sprite, ok := overlay.art[name]; ok {
+		return sprite
+	}
+	art, ok := overlay.objectAssets.GetImage(name).(assets.ObjectImageAsset)
+	if !ok {
+		return nil
+	}
+	sprite := pixel.NewSprite(art.Sheet, art.GetImages()[name])
+	overlay.art[name] = sprite
+	return sprite
+}
+
+// drawLabel writes text centered on position
+func drawLabel(win *pixelgl.Window, label string, position pixel.Vec, color color.Color) {
+	txt := text.New(pixel.ZV, countAtlas)
+	txt.Color = color
+	fmt.Fprint(txt, label)
+	txt.Draw(win, pixel.IM.Scaled(pixel.ZV, countScale).Moved(position.Sub(txt.Bounds().Center().Scaled(countScale))))
+}
+
+// canAnswer checks if the selection is a valid answer to the decision
+func (overlay *DecisionOverlay) canAnswer() bool {
+	return overlay.decision != nil && overlay.decision.Check(overlay.picks()) == nil
+}
+
+// picks returns the selected options in order
+func (overlay *DecisionOverlay) picks() []int {
+	picks := make([]int, 0, len(overlay.picked))
+	for pick := range overlay.picked {
+		picks = append(picks, pick)
+	}
+	sort.Ints(picks)
+	return picks
+}
+
+// Select records what in the overlay is at position, false if it is on neither an option nor done
+func (overlay *DecisionOverlay) Select(position pixel.Vec) bool {
+	overlay.clicked = noClick
+	if overlay.doneBox.Contains(position) {
+		overlay.clicked = doneClick
+		return true
+	}
+	for index, box := range overlay.optionBoxes {
+		if box.Contains(position) {
+			overlay.clicked = index
+			return true
+		}
+	}
+	return false
+}
+
+func (overlay *DecisionOverlay) SetHitBox() {
+	overlay.layout()
+}
+
+func (overlay *DecisionOverlay) GetHitBox() pixel.Rect {
+	return overlay.hitBox
+}
+
+func (overlay *DecisionOverlay) GetPosition() pixel.Vec {
+	return overlay.position
+}
+
+func (overlay *DecisionOverlay) MoveToPosition(position pixel.Vec) {
+	overlay.position = position
+	overlay.SetHitBox()
+}
+
+func (overlay *DecisionOverlay) GetObservable() *observable.Observable {
+	return overlay.observable
+}
+
+func newDecisionOverlayFSM() *objects.StateMachine {
+	return &objects.StateMachine{
+		States: objects.States{
+			objects.Default: objects.State{
+				Action: &ChooseAction{},
+				Events: objects.Events{
+					Choose: Operational,
+				},
+			},
+			Operational: objects.State{
+				Action: &ChooseAction{},
+				Events: objects.Events{
+					Choose: Operational,
+				},
+			},
+		},
+	}
+}
+
+// NewDecisionOverlayObject creates the prompt a seat answers its decisions through, position is the top middle of the prompt
+func NewDecisionOverlayObject(objectAssets assets.ObjectAssets, game *rules.GameState, seat int, position pixel.Vec) DecisionOverlay {
+	overlay := DecisionOverlay{
+		id:           objects.NextID,
+		stateMachine: newDecisionOverlayFSM(),
+		currentState: Operational,
+		game:         game,
+		seat:         seat,
+		objectAssets: objectAssets,
+		art:          make(map[string]*pixel.Sprite),
+		picked:       make(map[int]bool),
+		clicked:      noClick,
+		position:     position,
+		observable:   observable.NewObservable(),
+	}
+	overlay.width, overlay.height = cardSize(objectAssets)
+
+	overlay.SetHitBox()
+	objects.NextID++
+
+	return overlay
+}
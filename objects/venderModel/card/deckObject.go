@@ -1,7 +1,6 @@
 package card
 
 import (
-	"math/rand"
 	"sync"
 
 	"github.com/gopxl/pixel"
@@ -10,10 +9,14 @@ import (
 	"github.com/quartermeat/card_game/assets"
 	"github.com/quartermeat/card_game/objects"
 	"github.com/quartermeat/card_game/observable"
+	"github.com/quartermeat/card_game/rules"
 )
 
+// Deck is the view of a supply pile
 type Deck struct {
-	cards      	 []ICard
+	pile       	 *rules.Pile
+	objectAssets assets.ObjectAssets
+	game         *rules.GameState
 	asset 		 assets.ObjectImageAsset
 	position   	 pixel.Vec
 	hitBox     	 pixel.Rect
@@ -64,7 +67,10 @@ func (deck *Deck) Update(dt float64, gameObjects objects.GameObjects, waitGroup
 }
 
 func (deck *Deck) Draw(win *pixelgl.Window, drawHitBox bool, waitGroup *sync.WaitGroup) {
-	for _, card := range deck.cards {
+	for index, model := range deck.pile.Cards {
+		card := viewOf(deck.objectAssets, model)
+		card.SetState(Hidden)
+		card.MoveToPosition(stackPosition(deck.position, index))
 		waitGroup.Add(1)
 		card.Draw(win, drawHitBox, waitGroup)
 	}
@@ -95,40 +101,13 @@ func (deck *Deck) MoveToPosition(position pixel.Vec) {
 	deck.SetHitBox()
 }
 
-func (deck *Deck) Shuffle() {
-	rand.Shuffle(len(deck.cards), func(i, j int) {
-		deck.cards[i], deck.cards[j] = deck.cards[j], deck.cards[i]
-	})
-}
-
 func (deck *Deck) GetPosition() pixel.Vec{
 	return deck.position
 }
 
-func (deck *Deck) Deal() ICard {
-	if len(deck.cards) == 0 {
-		return nil
-	}
-
-	card := deck.cards[0]
-	deck.cards = deck.cards[1:]
-
-	return card
-}
-
-func (deck *Deck) AddCard(card ICard) {
-	deck.cards = append(deck.cards, card)
-}
-
-func (deck *Deck) PullCard() ICard {
-	if len(deck.cards) == 0 {
-		return nil
-	}
-
-	card := deck.cards[len(deck.cards)-1]
-	deck.cards = deck.cards[:len(deck.cards)-1]
-
-	return card
+// GetPile returns the supply pile this deck is a view of
+func (deck *Deck) GetPile() *rules.Pile {
+	return deck.pile
 }
 
 func (deck *Deck) GetObservable() *observable.Observable {
@@ -139,15 +118,15 @@ func newDeckFSM() *objects.StateMachine {
 	return &objects.StateMachine{
 		States: objects.States{
 			objects.Default: objects.State{
-				Action: &PullAction{},
+				Action: &BuyAction{},
 				Events: objects.Events{
-					Pull: Operational,
+					Buy: Operational,
 				},
 			},
 			Operational: objects.State{
-				Action: &PullAction{},
+				Action: &BuyAction{},
 				Events: objects.Events{
-					Pull: Operational,
+					Buy: Operational,
 				},
 			},
 			Empty: objects.State{
@@ -158,13 +137,15 @@ func newDeckFSM() *objects.StateMachine {
 	}
 }
 
-// NewDeck creates a new deck object containing a set number of card objects
-func NewDeckObject(assets assets.ObjectAssets, numCards int, card_type string, position pixel.Vec) Deck {
+// NewDeckObject creates a view of a supply pile, selecting it buys from the pile
+func NewDeckObject(assets assets.ObjectAssets, game *rules.GameState, pile *rules.Pile, position pixel.Vec) Deck {
 	deck := Deck{
 		id:		 	objects.NextID,
 		stateMachine: newDeckFSM(),
 		currentState: Operational,
-		cards:      make([]ICard, 0, numCards),
+		pile:       pile,
+		objectAssets: assets,
+		game:       game,
 		position:   position,
 		matrix:     pixel.IM.Moved(position),
 		observable: observable.NewObservable(),
@@ -172,21 +153,7 @@ func NewDeckObject(assets assets.ObjectAssets, numCards int, card_type string, p
 		dir: 	  0.0,
 		vel: 	 pixel.V(0, 0),
 	}
-
-	temp_position := deck.position
-	
-	for i := 0; i < numCards; i++ {
-		if(i % 13 == 0)	{
-			temp_position.X += 2
-			temp_position.Y += 2
-		}		
-		card := NewCardObject(assets, temp_position, card_type, Hidden)
-		deck.cards = append(deck.cards, &card)
-		if(i == numCards -1)	{
-			deck.width = card.front_sprite.Frame().Max.X - card.front_sprite.Frame().Min.X
-			deck.height = card.front_sprite.Frame().Max.Y - card.front_sprite.Frame().Min.Y
-		}
-	}
+	deck.width, deck.height = cardSize(assets)
 
 	deck.SetHitBox()
 	objects.NextID++
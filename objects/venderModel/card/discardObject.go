@@ -0,0 +1,150 @@
+package card
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/imdraw"
+	"github.com/gopxl/pixel/pixelgl"
+	"github.com/gopxl/pixel/text"
+	"github.com/quartermeat/card_game/assets"
+	"github.com/quartermeat/card_game/objects"
+	"github.com/quartermeat/card_game/observable"
+	"github.com/quartermeat/card_game/rules"
+	"golang.org/x/image/colornames"
+	"golang.org/x/image/font/basicfont"
+)
+
+// countAtlas is the font used to label piles with how many cards they hold
+var countAtlas = text.NewAtlas(basicfont.Face7x13, text.ASCII)
+
+// countScale makes the label readable at the zoom the table is shown at
+const countScale = 4
+
+// DiscardPile is the view of a player's discard pile, showing its top card and how many cards are in it
+type DiscardPile struct {
+	pile         *rules.Pile
+	objectAssets assets.ObjectAssets
+	asset        assets.ObjectImageAsset
+	position     pixel.Vec
+	hitBox       pixel.Rect
+	matrix       pixel.Matrix
+	observable   *observable.Observable
+	stateMachine *objects.StateMachine
+	currentState objects.StateType
+	id           int
+	counter      float64
+	sprite       *pixel.Sprite
+	height       float64
+	width        float64
+}
+
+// ObjectName is the string identifier for the object
+func (discard *DiscardPile) ObjectName() string {
+	return "DiscardPile"
+}
+
+func (discard *DiscardPile) GetFSM() *objects.StateMachine {
+	return discard.stateMachine
+}
+
+func (discard *DiscardPile) Sprite() *pixel.Sprite {
+	return discard.sprite
+}
+
+func (discard *DiscardPile) GetAssets() assets.IObjectAsset {
+	return discard.asset
+}
+
+func (discard *DiscardPile) Selectable() bool {
+	return true
+}
+
+func (discard *DiscardPile) GetID() int {
+	return discard.id
+}
+
+func (discard *DiscardPile) Update(dt float64, gameObjects objects.GameObjects, waitGroup *sync.WaitGroup) {
+	discard.counter += dt
+	//dummy object, with no updates atm
+	waitGroup.Done()
+}
+
+func (discard *DiscardPile) Draw(win *pixelgl.Window, drawHitBox bool, waitGroup *sync.WaitGroup) {
+	if top := discard.pile.Top(); top != nil {
+		card := viewOf(discard.objectAssets, top)
+		card.SetState(Up)
+		card.MoveToPosition(discard.position)
+		waitGroup.Add(1)
+		card.Draw(win, false, waitGroup)
+	}
+	drawCount(win, discard.position, discard.height, discard.pile.Len())
+
+	if drawHitBox {
+		imd := imdraw.New(nil)
+		imd.Color = pixel.RGB(0, 255, 0)
+		imd.Push(discard.GetHitBox().Min, discard.GetHitBox().Max)
+		imd.Rectangle(1)
+		imd.Draw(win)
+	}
+	waitGroup.Done()
+}
+
+func (discard *DiscardPile) SetHitBox() {
+	topRight := pixel.V(discard.position.X-discard.width/2, discard.position.Y-discard.height/2)
+	bottomLeft := pixel.V(discard.position.X+discard.width/2, discard.position.Y+discard.height/2)
+	discard.hitBox = pixel.R(topRight.X, topRight.Y, bottomLeft.X, bottomLeft.Y)
+}
+
+func (discard *DiscardPile) GetHitBox() pixel.Rect {
+	return discard.hitBox
+}
+
+func (discard *DiscardPile) GetPosition() pixel.Vec {
+	return discard.position
+}
+
+func (discard *DiscardPile) MoveToPosition(position pixel.Vec) {
+	discard.position = position
+	discard.matrix = pixel.IM.Moved(position)
+	discard.SetHitBox()
+}
+
+// GetPile returns the discard pile this object is a view of
+func (discard *DiscardPile) GetPile() *rules.Pile {
+	return discard.pile
+}
+
+func (discard *DiscardPile) GetObservable() *observable.Observable {
+	return discard.observable
+}
+
+// drawCount labels a pile drawn at position with its number of cards, just under the pile
+func drawCount(win *pixelgl.Window, position pixel.Vec, height float64, count int) {
+	label := text.New(pixel.ZV, countAtlas)
+	label.Color = colornames.White
+	fmt.Fprintf(label, "%d", count)
+	labelPosition := position.Sub(pixel.V(label.Bounds().W()*countScale/2, height/2+label.Bounds().H()*countScale))
+	label.Draw(win, pixel.IM.Scaled(pixel.ZV, countScale).Moved(labelPosition))
+}
+
+// NewDiscardPileObject creates a view of a player's discard pile
+func NewDiscardPileObject(assets assets.ObjectAssets, pile *rules.Pile, position pixel.Vec) DiscardPile {
+	discard := DiscardPile{
+		id:           objects.NextID,
+		stateMachine: &objects.StateMachine{States: objects.States{}},
+		currentState: Operational,
+		pile:         pile,
+		objectAssets: assets,
+		position:     position,
+		matrix:       pixel.IM.Moved(position),
+		observable:   observable.NewObservable(),
+	}
+	discard.width, discard.height = cardSize(assets)
+
+	discard.SetHitBox()
+	objects.NextID++
+
+	return discard
+}
@@ -2,7 +2,6 @@ package card
 
 import (
 	"math"
-	"math/rand"
 	"sync"
 
 	"github.com/gopxl/pixel"
@@ -10,10 +9,16 @@ import (
 	"github.com/quartermeat/card_game/assets"
 	"github.com/quartermeat/card_game/objects"
 	"github.com/quartermeat/card_game/observable"
+	"github.com/quartermeat/card_game/rules"
 )
 
+// Hand is the view of a player's hand, fanned out so every card can be seen
 type Hand struct {
-	cards      	 []ICard
+	pile       	 *rules.Pile
+	objectAssets assets.ObjectAssets
+	game         *rules.GameState
+	player       *rules.Player
+	selected     *rules.Card
 	asset 		 assets.ObjectImageAsset
 	position   	 pixel.Vec
 	hitBox     	 pixel.Rect
@@ -64,27 +69,41 @@ func (hand *Hand) Update(dt float64, gameObjects objects.GameObjects, waitGroup
 }
 
 func (hand *Hand) Draw(win *pixelgl.Window, drawHitBox bool, waitGroup *sync.WaitGroup) {
+	numOfcards := len(hand.pile.Cards)
+	if numOfcards == 0 {
+		waitGroup.Done()
+		return
+	}
 
-	textureHeight := hand.cards[0].Sprite().Frame().H()
-	textureWidth := hand.cards[0].Sprite().Frame().W()
-	numOfcards := len(hand.cards)
+	textureHeight := hand.height
+	textureWidth := hand.width
 	interval := 90 * (math.Pi /180)
 	initialAngle := -45 * (math.Pi /180)
 	increment := interval / float64(numOfcards)
 	leftCorner := pixel.V(0,textureHeight * 0.9)
 	rightCorner := pixel.V(textureWidth, textureHeight * 0.9)
 
-	cardIndex := 0
-	for angle := 0.0; angle < interval; angle += increment{
+	hitBox := pixel.R(hand.position.X, hand.position.Y, hand.position.X, hand.position.Y)
+	for cardIndex, model := range hand.pile.Cards {
 		waitGroup.Add(1)
+		angle := float64(cardIndex) * increment
 		cardAngle := initialAngle + angle
 		cardOrigin := pixel.Lerp(rightCorner, leftCorner, angle/interval)
 		cardMatrix := pixel.IM.Rotated(pixel.ZV, cardAngle).Moved(cardOrigin.Add(hand.position))
-		hand.cards[cardIndex].SetMatrix((cardMatrix))
+		card := viewOf(hand.objectAssets, model)
+		// only the cards of a seat played by a person are shown
+		if hand.player.Human {
+			card.SetState(Operational)
+		} else {
+			card.SetState(Down)
+		}
+		card.SetMatrix((cardMatrix))
+		hitBox = hitBox.Union(cardBounds(cardMatrix, textureWidth, textureHeight))
 		//hard coded not drawing hit box for now, need to fix hit box for cards in a hand/deck
-		hand.cards[cardIndex].Draw(win, false, waitGroup)
-		cardIndex++
-	}	
+		card.Draw(win, false, waitGroup)
+	}
+	// the fanned out cards change with the hand, so the hit box follows what was drawn
+	hand.hitBox = hitBox
 	waitGroup.Done()
 }
 
@@ -104,40 +123,27 @@ func (hand *Hand) MoveToPosition(position pixel.Vec) {
 	hand.SetHitBox()
 }
 
-func (hand *Hand) Shuffle() {
-	rand.Shuffle(len(hand.cards), func(i, j int) {
-		hand.cards[i], hand.cards[j] = hand.cards[j], hand.cards[i]
-	})
-}
-
-func (hand *Hand) Deal() ICard {
-	if len(hand.cards) == 0 {
-		return nil
-	}
-
-	card := hand.cards[0]
-	hand.cards = hand.cards[1:]
-
-	return card
-}
-
 func (hand *Hand) GetPosition() pixel.Vec{
 	return hand.position
 }
 
-func (hand *Hand) AddCard(card ICard) {
-	hand.cards = append(hand.cards, card)
-}
-
-func (hand *Hand) PullCard() ICard {
-	if len(hand.cards) == 0 {
-		return nil
+// Select picks the card drawn at position to be played next, false if there is no card there
+func (hand *Hand) Select(position pixel.Vec) bool {
+	hand.selected = nil
+	// the last card is drawn on top, so check from the top down
+	for index := len(hand.pile.Cards) - 1; index >= 0; index-- {
+		model := hand.pile.Cards[index]
+		if viewOf(hand.objectAssets, model).Contains(position) {
+			hand.selected = model
+			return true
+		}
 	}
+	return false
+}
 
-	card := hand.cards[0]
-	hand.cards = hand.cards[1:]
-
-	return card
+// GetPile returns the hand this object is a view of
+func (hand *Hand) GetPile() *rules.Pile {
+	return hand.pile
 }
 
 func (hand *Hand) GetObservable() *observable.Observable {
@@ -166,15 +172,16 @@ func newHandFSM() *objects.StateMachine {
 	}
 }
 
-// NewHand creates a new hand object containing a set number of card objects
-func NewHandObject(assets assets.ObjectAssets, position pixel.Vec) Hand {
-	numCards := 5
-	
+// NewHandObject creates a view of a player's hand, cards selected in it are played by the player
+func NewHandObject(assets assets.ObjectAssets, game *rules.GameState, player *rules.Player, position pixel.Vec) Hand {
 	hand := Hand{
 		id:		 	objects.NextID,
 		stateMachine: newHandFSM(),
 		currentState: Operational,
-		cards:      make([]ICard, 0, numCards),
+		pile:       player.Hand,
+		objectAssets: assets,
+		game:       game,
+		player:     player,
 		position:   position,
 		matrix:     pixel.IM.Moved(position),
 		observable: observable.NewObservable(),
@@ -182,20 +189,20 @@ func NewHandObject(assets assets.ObjectAssets, position pixel.Vec) Hand {
 		dir: 	  0.0,
 		vel: 	 pixel.V(0, 0),
 	}
-
-	//need to implement to setup a default hand with specific cards per dominion rules
-	temp_position := hand.position
-	
-	for i := 0; i < numCards; i++ {
-		card := NewCardObject(assets, temp_position, "zombies", Operational)
-		hand.cards = append(hand.cards, &card)
-		if(i == numCards -1)	{
-			hand.width = card.front_sprite.Frame().Max.X - card.front_sprite.Frame().Min.X
-			hand.height = card.front_sprite.Frame().Max.Y - card.front_sprite.Frame().Min.Y
-		}
-	}
+	hand.width, hand.height = cardSize(assets)
 
 	objects.NextID++
 
 	return hand
 }
+
+// cardBounds returns the axis aligned box around a card drawn with matrix
+func cardBounds(matrix pixel.Matrix, width float64, height float64) pixel.Rect {
+	first := matrix.Project(pixel.V(-width/2, -height/2))
+	bounds := pixel.R(first.X, first.Y, first.X, first.Y)
+	for _, corner := range []pixel.Vec{pixel.V(width/2, -height/2), pixel.V(width/2, height/2), pixel.V(-width/2, height/2)} {
+		projected := matrix.Project(corner)
+		bounds = bounds.Union(pixel.R(projected.X, projected.Y, projected.X, projected.Y))
+	}
+	return bounds
+}
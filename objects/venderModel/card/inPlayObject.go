@@ -0,0 +1,152 @@
+package card
+
+import (
+	"sync"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/imdraw"
+	"github.com/gopxl/pixel/pixelgl"
+	"github.com/quartermeat/card_game/assets"
+	"github.com/quartermeat/card_game/objects"
+	"github.com/quartermeat/card_game/observable"
+	"github.com/quartermeat/card_game/rules"
+)
+
+// the row of cards in play squeezes together once it holds more than this many cards
+const inPlayColumns = 8
+
+// InPlay is the view of the cards a player has played this turn, laid out in a row from left to right
+// in the order they were played. The rules engine discards them at cleanup.
+type InPlay struct {
+	pile         *rules.Pile
+	objectAssets assets.ObjectAssets
+	player       *rules.Player
+	asset        assets.ObjectImageAsset
+	position     pixel.Vec
+	hitBox       pixel.Rect
+	matrix       pixel.Matrix
+	observable   *observable.Observable
+	stateMachine *objects.StateMachine
+	currentState objects.StateType
+	id           int
+	counter      float64
+	sprite       *pixel.Sprite
+	height       float64
+	width        float64
+}
+
+// ObjectName is the string identifier for the object
+func (inPlay *InPlay) ObjectName() string {
+	return "InPlay"
+}
+
+func (inPlay *InPlay) GetFSM() *objects.StateMachine {
+	return inPlay.stateMachine
+}
+
+func (inPlay *InPlay) Sprite() *pixel.Sprite {
+	return inPlay.sprite
+}
+
+func (inPlay *InPlay) GetAssets() assets.IObjectAsset {
+	return inPlay.asset
+}
+
+func (inPlay *InPlay) Selectable() bool {
+	return false
+}
+
+func (inPlay *InPlay) GetID() int {
+	return inPlay.id
+}
+
+func (inPlay *InPlay) Update(dt float64, gameObjects objects.GameObjects, waitGroup *sync.WaitGroup) {
+	inPlay.counter += dt
+	//dummy object, with no updates atm
+	waitGroup.Done()
+}
+
+func (inPlay *InPlay) Draw(win *pixelgl.Window, drawHitBox bool, waitGroup *sync.WaitGroup) {
+	spacing := inPlay.spacing()
+	for index, model := range inPlay.pile.Cards {
+		card := viewOf(inPlay.objectAssets, model)
+		card.SetState(Up)
+		card.MoveToPosition(inPlay.position.Add(pixel.V(float64(index)*spacing, 0)))
+		waitGroup.Add(1)
+		card.Draw(win, false, waitGroup)
+	}
+	inPlay.SetHitBox()
+
+	if drawHitBox {
+		imd := imdraw.New(nil)
+		imd.Color = pixel.RGB(0, 255, 0)
+		imd.Push(inPlay.GetHitBox().Min, inPlay.GetHitBox().Max)
+		imd.Rectangle(1)
+		imd.Draw(win)
+	}
+	waitGroup.Done()
+}
+
+// spacing is how far apart the cards in the row are, they overlap once the row is full
+func (inPlay *InPlay) spacing() float64 {
+	if inPlay.pile.Len() > inPlayColumns {
+		return inPlay.width * float64(inPlayColumns-1) / float64(inPlay.pile.Len()-1)
+	}
+	return inPlay.width
+}
+
+// SetHitBox covers the whole row, starting with the space for the first card
+func (inPlay *InPlay) SetHitBox() {
+	cards := inPlay.pile.Len()
+	if cards == 0 {
+		cards = 1
+	}
+	length := float64(cards-1)*inPlay.spacing() + inPlay.width
+	bottomLeft := pixel.V(inPlay.position.X-inPlay.width/2, inPlay.position.Y-inPlay.height/2)
+	topRight := pixel.V(bottomLeft.X+length, inPlay.position.Y+inPlay.height/2)
+	inPlay.hitBox = pixel.R(bottomLeft.X, bottomLeft.Y, topRight.X, topRight.Y)
+}
+
+func (inPlay *InPlay) GetHitBox() pixel.Rect {
+	return inPlay.hitBox
+}
+
+func (inPlay *InPlay) GetPosition() pixel.Vec {
+	return inPlay.position
+}
+
+func (inPlay *InPlay) MoveToPosition(position pixel.Vec) {
+	inPlay.position = position
+	inPlay.matrix = pixel.IM.Moved(position)
+	inPlay.SetHitBox()
+}
+
+// GetPile returns the in play zone this object is a view of
+func (inPlay *InPlay) GetPile() *rules.Pile {
+	return inPlay.pile
+}
+
+func (inPlay *InPlay) GetObservable() *observable.Observable {
+	return inPlay.observable
+}
+
+// NewInPlayObject creates a view of the cards a player has in play, position is where the first card goes
+func NewInPlayObject(assets assets.ObjectAssets, player *rules.Player, position pixel.Vec) InPlay {
+	inPlay := InPlay{
+		id:           objects.NextID,
+		stateMachine: &objects.StateMachine{States: objects.States{}},
+		currentState: Operational,
+		pile:         player.InPlay,
+		objectAssets: assets,
+		player:       player,
+		position:     position,
+		matrix:       pixel.IM.Moved(position),
+		observable:   observable.NewObservable(),
+	}
+	inPlay.width, inPlay.height = cardSize(assets)
+
+	inPlay.SetHitBox()
+	objects.NextID++
+
+	return inPlay
+}
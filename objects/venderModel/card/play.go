@@ -3,17 +3,27 @@ package card
 import (
 	"fmt"
 
+	"github.com/quartermeat/card_game/gamestates"
 	"github.com/quartermeat/card_game/objects"
 )
 
-// PullAction represents the pull action for a card.
+// PlayAction represents playing the selected card from a hand.
 type PlayAction struct{}
 
-// Execute executes the pull action.
+// Execute plays the card selected in the hand and resolves its effects.
 func (pa *PlayAction) Execute(gameObj objects.IGameObject) objects.EventType{
-	fmt.Printf("Playing card from hand\n")
-	// Add play card logic here
-	// hand := gameObj.(IHand)
+	hand := gameObj.(*Hand)
+	// the hand on the table belongs to the player, so it can only be played from on their turn
+	if hand.selected == nil || hand.game.StateManager.GetCurrentState() != gamestates.PlayerTurn {
+		hand.selected = nil
+		return objects.NoOp
+	}
+
+	fmt.Printf("Playing %s from hand\n", hand.selected.Name)
+	if err := hand.game.Play(hand.player, hand.selected); err != nil {
+		fmt.Printf("can't play %s: %s\n", hand.selected.Name, err)
+	}
+	hand.selected = nil
 
 	return objects.NoOp
 }
@@ -1,7 +1,6 @@
 package card
 
 import (
-	"math/rand"
 	"sync"
 
 	"github.com/gopxl/pixel"
@@ -10,10 +9,14 @@ import (
 	"github.com/quartermeat/card_game/assets"
 	"github.com/quartermeat/card_game/objects"
 	"github.com/quartermeat/card_game/observable"
+	"github.com/quartermeat/card_game/rules"
 )
 
+// PlayerDeck is the view of a player's draw pile
 type PlayerDeck struct {
-	cards      	 []ICard
+	pile       	 *rules.Pile
+	objectAssets assets.ObjectAssets
+	player       *rules.Player
 	asset 		 assets.ObjectImageAsset
 	position   	 pixel.Vec
 	hitBox     	 pixel.Rect
@@ -64,7 +67,10 @@ func (playerDeck *PlayerDeck) Update(dt float64, gameObjects objects.GameObjects
 }
 
 func (playerDeck *PlayerDeck) Draw(win *pixelgl.Window, drawHitBox bool, waitGroup *sync.WaitGroup) {
-	for _, card := range playerDeck.cards {
+	for index, model := range playerDeck.pile.Cards {
+		card := viewOf(playerDeck.objectAssets, model)
+		card.SetState(Down)
+		card.MoveToPosition(stackPosition(playerDeck.position, index))
 		waitGroup.Add(1)
 		card.Draw(win, drawHitBox, waitGroup)
 	}
@@ -95,40 +101,13 @@ func (playerDeck *PlayerDeck) MoveToPosition(position pixel.Vec) {
 	playerDeck.SetHitBox()
 }
 
-func (playerDeck *PlayerDeck) Shuffle() {
-	rand.Shuffle(len(playerDeck.cards), func(i, j int) {
-		playerDeck.cards[i], playerDeck.cards[j] = playerDeck.cards[j], playerDeck.cards[i]
-	})
-}
-
-func (playerDeck *PlayerDeck) Deal() ICard {
-	if len(playerDeck.cards) == 0 {
-		return nil
-	}
-
-	card := playerDeck.cards[0]
-	playerDeck.cards = playerDeck.cards[1:]
-
-	return card
-}
-
 func (playerDeck *PlayerDeck) GetPosition() pixel.Vec{
 	return playerDeck.position
 }
 
-func (playerDeck *PlayerDeck) AddCard(card ICard) {
-	playerDeck.cards = append(playerDeck.cards, card)
-}
-
-func (playerDeck *PlayerDeck) PullCard() ICard {
-	if len(playerDeck.cards) == 0 {
-		return nil
-	}
-
-	card := playerDeck.cards[len(playerDeck.cards)-1]
-	playerDeck.cards = playerDeck.cards[:len(playerDeck.cards)-1]
-
-	return card
+// GetPile returns the draw pile this deck is a view of
+func (playerDeck *PlayerDeck) GetPile() *rules.Pile {
+	return playerDeck.pile
 }
 
 func (playerDeck *PlayerDeck) GetObservable() *observable.Observable {
@@ -138,16 +117,13 @@ func (playerDeck *PlayerDeck) GetObservable() *observable.Observable {
 func newPlayerDeckFSM() *objects.StateMachine {
 	return &objects.StateMachine{
 		States: objects.States{
+			// cards are only drawn by the rules engine, in cleanup and by card effects
 			objects.Default: objects.State{
-				Action: &PullAction{},
 				Events: objects.Events{
-					Pull: Operational,
 				},
 			},
 			Operational: objects.State{
-				Action: &PullAction{},
 				Events: objects.Events{
-					Pull: Operational,
 				},
 			},
 			Empty: objects.State{
@@ -158,13 +134,15 @@ func newPlayerDeckFSM() *objects.StateMachine {
 	}
 }
 
-// NewPlayerDeckObject creates a new playerDeck object containing a set number of card objects
-func NewPlayerDeckObject(assets assets.ObjectAssets, position pixel.Vec) PlayerDeck {
+// NewPlayerDeckObject creates a view of a player's draw pile
+func NewPlayerDeckObject(assets assets.ObjectAssets, player *rules.Player, position pixel.Vec) PlayerDeck {
 	playerDeck := PlayerDeck{
 		id:		 	objects.NextID,
 		stateMachine: newPlayerDeckFSM(),
 		currentState: Operational,
-		cards:      make([]ICard, 0, 10),
+		pile:       player.Deck,
+		objectAssets: assets,
+		player:     player,
 		position:   position,
 		matrix:     pixel.IM.Moved(position),
 		observable: observable.NewObservable(),
@@ -172,29 +150,7 @@ func NewPlayerDeckObject(assets assets.ObjectAssets, position pixel.Vec) PlayerD
 		dir: 	  0.0,
 		vel: 	 pixel.V(0, 0),
 	}
-
-	temp_position := playerDeck.position
-	var card_type string
-
-	for i := 0; i < 10; i++ {
-		if(i % 13 == 0)	{
-			temp_position.X += 2
-			temp_position.Y += 2
-		}
-		if(i < 3){
-			card_type = "zombies"
-		}else{
-			card_type = "bullet"
-		}
-		card := NewCardObject(assets, temp_position, card_type, Down)
-		playerDeck.cards = append(playerDeck.cards, &card)
-		if(i == 9)	{
-			playerDeck.width = card.front_sprite.Frame().Max.X - card.front_sprite.Frame().Min.X
-			playerDeck.height = card.front_sprite.Frame().Max.Y - card.front_sprite.Frame().Min.Y
-		}
-	}
-
-	playerDeck.Shuffle()
+	playerDeck.width, playerDeck.height = cardSize(assets)
 
 	playerDeck.SetHitBox()
 	objects.NextID++
@@ -0,0 +1,190 @@
+package card
+
+import (
+	"sync"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/imdraw"
+	"github.com/gopxl/pixel/pixelgl"
+	"github.com/quartermeat/card_game/assets"
+	"github.com/quartermeat/card_game/objects"
+	"github.com/quartermeat/card_game/observable"
+	"github.com/quartermeat/card_game/rules"
+)
+
+// cards per row when browsing the trash
+const browseColumns = 8
+
+// Trash is the view of the shared trash, showing the last card trashed. Selecting it opens
+// the whole trash laid out next to it, selecting it again closes it.
+type Trash struct {
+	pile         *rules.Pile
+	objectAssets assets.ObjectAssets
+	asset        assets.ObjectImageAsset
+	position     pixel.Vec
+	hitBox       pixel.Rect
+	matrix       pixel.Matrix
+	observable   *observable.Observable
+	stateMachine *objects.StateMachine
+	currentState objects.StateType
+	id           int
+	counter      float64
+	sprite       *pixel.Sprite
+	height       float64
+	width        float64
+	browsing     bool
+}
+
+// ObjectName is the string identifier for the object
+func (trash *Trash) ObjectName() string {
+	return "Trash"
+}
+
+func (trash *Trash) GetFSM() *objects.StateMachine {
+	return trash.stateMachine
+}
+
+func (trash *Trash) Sprite() *pixel.Sprite {
+	return trash.sprite
+}
+
+func (trash *Trash) GetAssets() assets.IObjectAsset {
+	return trash.asset
+}
+
+func (trash *Trash) Selectable() bool {
+	return true
+}
+
+func (trash *Trash) GetID() int {
+	return trash.id
+}
+
+func (trash *Trash) Update(dt float64, gameObjects objects.GameObjects, waitGroup *sync.WaitGroup) {
+	trash.counter += dt
+	//dummy object, with no updates atm
+	waitGroup.Done()
+}
+
+func (trash *Trash) Draw(win *pixelgl.Window, drawHitBox bool, waitGroup *sync.WaitGroup) {
+	// the trash art marks the spot until something is trashed
+	if top := trash.pile.Top(); top != nil {
+		card := viewOf(trash.objectAssets, top)
+		card.SetState(Up)
+		card.MoveToPosition(trash.position)
+		waitGroup.Add(1)
+		card.Draw(win, false, waitGroup)
+	} else {
+		trash.sprite.Draw(win, trash.matrix)
+	}
+	drawCount(win, trash.position, trash.height, trash.pile.Len())
+
+	if trash.browsing {
+		trash.drawContents(win, waitGroup)
+	}
+
+	if drawHitBox {
+		imd := imdraw.New(nil)
+		imd.Color = pixel.RGB(0, 255, 0)
+		imd.Push(trash.GetHitBox().Min, trash.GetHitBox().Max)
+		imd.Rectangle(1)
+		imd.Draw(win)
+	}
+	waitGroup.Done()
+}
+
+// drawContents lays every trashed card out face up in rows under the trash, oldest first
+func (trash *Trash) drawContents(win *pixelgl.Window, waitGroup *sync.WaitGroup) {
+	if trash.pile.Len() == 0 {
+		return
+	}
+	rows := (trash.pile.Len()-1)/browseColumns + 1
+	start := trash.position.Sub(pixel.V(0, trash.height*1.5))
+	background := imdraw.New(nil)
+	background.Color = pixel.RGBA{R: 0, G: 0, B: 0, A: 0.8}
+	background.Push(
+		start.Sub(pixel.V(trash.width*0.75, trash.height*(float64(rows)-0.5)+trash.height/4)),
+		start.Add(pixel.V(trash.width*(float64(browseColumns)-0.25), trash.height*0.75)),
+	)
+	background.Rectangle(0)
+	background.Draw(win)
+
+	for index, model := range trash.pile.Cards {
+		card := viewOf(trash.objectAssets, model)
+		card.SetState(Up)
+		card.MoveToPosition(start.Add(pixel.V(float64(index%browseColumns)*trash.width, -float64(index/browseColumns)*trash.height)))
+		waitGroup.Add(1)
+		card.Draw(win, false, waitGroup)
+	}
+}
+
+func (trash *Trash) SetHitBox() {
+	topRight := pixel.V(trash.position.X-trash.width/2, trash.position.Y-trash.height/2)
+	bottomLeft := pixel.V(trash.position.X+trash.width/2, trash.position.Y+trash.height/2)
+	trash.hitBox = pixel.R(topRight.X, topRight.Y, bottomLeft.X, bottomLeft.Y)
+}
+
+func (trash *Trash) GetHitBox() pixel.Rect {
+	return trash.hitBox
+}
+
+func (trash *Trash) GetPosition() pixel.Vec {
+	return trash.position
+}
+
+func (trash *Trash) MoveToPosition(position pixel.Vec) {
+	trash.position = position
+	trash.matrix = pixel.IM.Moved(position)
+	trash.SetHitBox()
+}
+
+// GetPile returns the trash pile this object is a view of
+func (trash *Trash) GetPile() *rules.Pile {
+	return trash.pile
+}
+
+func (trash *Trash) GetObservable() *observable.Observable {
+	return trash.observable
+}
+
+func newTrashFSM() *objects.StateMachine {
+	return &objects.StateMachine{
+		States: objects.States{
+			objects.Default: objects.State{
+				Action: &BrowseAction{},
+				Events: objects.Events{
+					Browse: Operational,
+				},
+			},
+			Operational: objects.State{
+				Action: &BrowseAction{},
+				Events: objects.Events{
+					Browse: Operational,
+				},
+			},
+		},
+	}
+}
+
+// NewTrashObject creates a view of the game's trash
+func NewTrashObject(objectAssets assets.ObjectAssets, game *rules.GameState, position pixel.Vec) Trash {
+	art := objectAssets.GetImage(TRASH).(assets.ObjectImageAsset)
+	trash := Trash{
+		id:           objects.NextID,
+		stateMachine: newTrashFSM(),
+		currentState: Operational,
+		pile:         game.Trash,
+		objectAssets: objectAssets,
+		asset:        art,
+		sprite:       pixel.NewSprite(art.Sheet, art.GetImages()[TRASH]),
+		position:     position,
+		matrix:       pixel.IM.Moved(position),
+		observable:   observable.NewObservable(),
+	}
+	trash.width, trash.height = cardSize(objectAssets)
+
+	trash.SetHitBox()
+	objects.NextID++
+
+	return trash
+}
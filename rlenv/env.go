@@ -0,0 +1,289 @@
+// Package rlenv wraps the rules engine as a reinforcement learning environment: an agent plays one
+// seat through numbered actions and fixed length observations, and bots play the other seats.
+// Serve offers the same environment as line delimited JSON over a reader and writer.
+package rlenv
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/quartermeat/card_game/gamestates"
+	"github.com/quartermeat/card_game/rules"
+	"github.com/quartermeat/card_game/strategy"
+)
+
+// TURN_LIMIT is how many turns the agent gets before a game that hasn't ended is called a draw
+const TURN_LIMIT = 100
+
+// the actions that don't name a card, the card actions come after them
+const (
+	END_TURN = iota
+	// CONFIRM answers the pending decision with the options picked so far
+	CONFIRM
+	YES
+	NO
+	card_actions
+)
+
+// what a card action does with its card
+const (
+	play_card = iota
+	buy_card
+	pick_card
+)
+
+// Config says what game the agent plays
+type Config struct {
+	Definitions rules.CardDefinitions
+	Kingdom     rules.KingdomConfig
+	// Opponents are the strategy specs of the other seats, in turn order after the agent
+	Opponents []string
+	// Seat is where the agent sits, -1 for a seat drawn from each game's seed
+	Seat int
+}
+
+// Env is a game the agent plays a seat of. Actions are numbered: END_TURN, CONFIRM, YES and NO,
+// then play, buy and pick for each card in the order of Cards. Decisions are answered a pick
+// at a time, by picking options by their card's name and then confirming.
+type Env struct {
+	config Config
+	// Cards are the names of every defined card, in the order actions and observations use
+	Cards     []string
+	index     map[string]int
+	opponents []strategy.Strategy
+	game      *rules.GameState
+	seat      int
+	// picks are the options picked so far for the pending decision
+	picks []string
+	done  bool
+}
+
+// New creates an environment, Reset starts its first game
+func New(config Config) (*Env, error) {
+	players := len(config.Opponents) + 1
+	if players < rules.MIN_PLAYERS || players > rules.MAX_PLAYERS {
+		return nil, errors.Errorf("games are for %d to %d seats, not %d", rules.MIN_PLAYERS, rules.MAX_PLAYERS, players)
+	}
+	if config.Seat >= players {
+		return nil, errors.Errorf("seat %d isn't in a game of %d", config.Seat, players)
+	}
+	env := &Env{config: config, Cards: config.Definitions.Names(), index: make(map[string]int), done: true}
+	for index, name := range env.Cards {
+		env.index[name] = index
+	}
+	for _, spec := range config.Opponents {
+		bot, err := strategy.New(spec, config.Definitions)
+		if err != nil {
+			return nil, err
+		}
+		env.opponents = append(env.opponents, bot)
+	}
+	return env, nil
+}
+
+// ActionCount is the number of actions, legal or not
+func (env *Env) ActionCount() int {
+	return card_actions + 3*len(env.Cards)
+}
+
+// ActionName describes an action, like "buy reload"
+func (env *Env) ActionName(action int) string {
+	switch {
+	case action == END_TURN:
+		return "end_turn"
+	case action == CONFIRM:
+		return "confirm"
+	case action == YES:
+		return "yes"
+	case action == NO:
+		return "no"
+	case action < card_actions || action >= env.ActionCount():
+		return fmt.Sprintf("unknown %d", action)
+	}
+	verb, card := env.cardAction(action)
+	return []string{"play", "buy", "pick"}[verb] + " " + card
+}
+
+// action returns the number of the action doing verb with a card
+func (env *Env) action(verb int, card string) int {
+	return card_actions + verb*len(env.Cards) + env.index[card]
+}
+
+// cardAction splits a card action into what it does and its card
+func (env *Env) cardAction(action int) (int, string) {
+	offset := action - card_actions
+	return offset / len(env.Cards), env.Cards[offset%len(env.Cards)]
+}
+
+// Seat returns the agent's seat in the current game
+func (env *Env) Seat() int {
+	return env.seat
+}
+
+// Game returns the game being played, to look at but not to change
+func (env *Env) Game() *rules.GameState {
+	return env.game
+}
+
+// Reset starts a new game from seed, playing the opponents up to the agent's first choice
+func (env *Env) Reset(seed int64) ([]float64, error) {
+	game := rules.NewGameState(env.config.Definitions, gamestates.NewStateManager(), seed)
+	if _, err := game.ChooseKingdom(env.config.Kingdom); err != nil {
+		return nil, err
+	}
+	players := len(env.opponents) + 1
+	if err := game.SetupSupply(rules.DefaultSetup, players); err != nil {
+		return nil, err
+	}
+	env.seat = env.config.Seat
+	if env.seat < 0 {
+		env.seat = game.Rand.Intn(players)
+	}
+	for seat := 0; seat < players; seat++ {
+		if seat == env.seat {
+			game.AddPlayer("agent", true)
+			continue
+		}
+		game.AddPlayer(fmt.Sprintf("ai%d", seat+1), false)
+		game.SetDecider(seat, env.opponent(seat))
+	}
+	env.game, env.picks, env.done = game, nil, false
+
+	game.Start()
+	if err := env.playOpponents(); err != nil {
+		return nil, err
+	}
+	return env.Observe(), nil
+}
+
+// opponent returns the strategy playing a seat other than the agent's
+func (env *Env) opponent(seat int) strategy.Strategy {
+	if seat > env.seat {
+		seat--
+	}
+	return env.opponents[seat]
+}
+
+// playOpponents plays the other seats until it is the agent's choice or the game is over
+func (env *Env) playOpponents() error {
+	for {
+		chooser := env.game.Chooser()
+		if chooser < 0 || chooser == env.seat || env.agentTurns() > TURN_LIMIT {
+			return nil
+		}
+		err := strategy.TakeTurn(env.game, env.opponent(chooser))
+		if err != nil && !(err == rules.ErrDecisionPending && env.game.Chooser() == env.seat) {
+			return err
+		}
+	}
+}
+
+func (env *Env) agentTurns() int {
+	return env.game.Players[env.seat].Turns
+}
+
+// LegalActions returns the actions the agent can take now, in increasing order
+func (env *Env) LegalActions() []int {
+	if env.done {
+		return nil
+	}
+	legal := make([]bool, env.ActionCount())
+	if decision := env.game.PendingDecision(); decision != nil {
+		if decision.Kind == rules.YES_NO {
+			legal[YES], legal[NO] = true, true
+		} else {
+			legal[CONFIRM] = len(env.picks) >= decision.Min
+			if len(env.picks) < decision.Max {
+				left := make(map[string]int)
+				for _, option := range decision.Options {
+					left[option.Name]++
+				}
+				for _, name := range env.picks {
+					left[name]--
+				}
+				for name, count := range left {
+					if count > 0 {
+						legal[env.action(pick_card, name)] = true
+					}
+				}
+			}
+		}
+	} else {
+		for _, move := range env.game.LegalMoves() {
+			switch move.Kind {
+			case rules.PLAY:
+				legal[env.action(play_card, move.Card)] = true
+			case rules.BUY:
+				legal[env.action(buy_card, move.Card)] = true
+			case rules.END_TURN:
+				legal[END_TURN] = true
+			}
+		}
+	}
+	actions := make([]int, 0)
+	for action, ok := range legal {
+		if ok {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// Step takes an action for the agent and plays the opponents up to its next choice. The reward
+// is 0 until the game is done, then 1 for a win and -1 for a loss, with a shared first place
+// in between. A game that passes the TURN_LIMIT is done with a reward of 0.
+func (env *Env) Step(action int) ([]float64, float64, bool, error) {
+	if env.done {
+		return nil, 0, true, errors.New("the game is done, reset to start another")
+	}
+	legal := false
+	for _, candidate := range env.LegalActions() {
+		legal = legal || candidate == action
+	}
+	if !legal {
+		return nil, 0, false, errors.Errorf("%s isn't a legal action", env.ActionName(action))
+	}
+
+	var err error
+	switch action {
+	case END_TURN:
+		err = env.game.Apply(rules.Move{Kind: rules.END_TURN})
+	case YES:
+		err = env.game.Answer([]int{rules.YES})
+	case NO:
+		err = env.game.Answer([]int{rules.NO})
+	case CONFIRM:
+		err = env.game.Apply(rules.Move{Kind: rules.ANSWER, Picks: env.picks})
+		env.picks = nil
+	default:
+		switch verb, card := env.cardAction(action); verb {
+		case play_card:
+			err = env.game.Apply(rules.Move{Kind: rules.PLAY, Card: card})
+		case buy_card:
+			err = env.game.Apply(rules.Move{Kind: rules.BUY, Card: card})
+		case pick_card:
+			env.picks = append(env.picks, card)
+		}
+	}
+	if err == nil {
+		err = env.playOpponents()
+	}
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	reward := 0.0
+	if env.game.Chooser() < 0 {
+		env.done = true
+		reward = -1
+		winners := env.game.Winners()
+		for _, winner := range winners {
+			if env.game.PlayerIndex(winner) == env.seat {
+				reward = 2/float64(len(winners)) - 1
+			}
+		}
+	} else if env.agentTurns() > TURN_LIMIT {
+		env.done = true
+	}
+	return env.Observe(), reward, env.done, nil
+}
@@ -0,0 +1,149 @@
+package rlenv
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/quartermeat/card_game/rules"
+)
+
+func loadDefinitions(t *testing.T) rules.CardDefinitions {
+	t.Helper()
+	definitions, err := rules.LoadCardDefinitions("../assets/cards/cardDefinitions.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return definitions
+}
+
+func newTestEnv(t *testing.T, kingdom rules.KingdomConfig, seat int, opponents ...string) *Env {
+	t.Helper()
+	env, err := New(Config{Definitions: loadDefinitions(t), Kingdom: kingdom, Opponents: opponents, Seat: seat})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return env
+}
+
+func hasAction(actions []int, action int) bool {
+	for _, candidate := range actions {
+		if candidate == action {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNewChecksTheSeats(t *testing.T) {
+	definitions := loadDefinitions(t)
+	if _, err := New(Config{Definitions: definitions}); err == nil {
+		t.Error("expected a game without opponents to fail")
+	}
+	if _, err := New(Config{Definitions: definitions, Opponents: []string{"random"}, Seat: 2}); err == nil {
+		t.Error("expected a seat outside the game to fail")
+	}
+	if _, err := New(Config{Definitions: definitions, Opponents: []string{"nobody"}}); err == nil {
+		t.Error("expected an unknown opponent to fail")
+	}
+}
+
+func TestRandomAgentFinishesGames(t *testing.T) {
+	for _, opponents := range [][]string{{"big_money"}, {"random", "big_money"}} {
+		env := newTestEnv(t, rules.DefaultKingdomConfig(), -1, opponents...)
+		rng := rand.New(rand.NewSource(1))
+		for seed := int64(1); seed <= 5; seed++ {
+			observation, err := env.Reset(seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			done, reward := false, 0.0
+			for steps := 0; !done; steps++ {
+				if len(observation) != env.ObservationSize() {
+					t.Fatalf("expected observations of %d numbers, got %d", env.ObservationSize(), len(observation))
+				}
+				if steps > 10000 {
+					t.Fatalf("seed %d didn't finish", seed)
+				}
+				legal := env.LegalActions()
+				if len(legal) == 0 {
+					t.Fatalf("expected a legal action in seed %d", seed)
+				}
+				observation, reward, done, err = env.Step(legal[rng.Intn(len(legal))])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !done && reward != 0 {
+					t.Fatalf("expected no reward before the game is done, got %v", reward)
+				}
+			}
+			if reward < -1 || reward > 1 {
+				t.Errorf("expected a reward between -1 and 1, got %v", reward)
+			}
+			if _, _, _, err := env.Step(END_TURN); err == nil {
+				t.Error("expected a step after the game is done to fail")
+			}
+		}
+	}
+}
+
+func TestStepRejectsIllegalActions(t *testing.T) {
+	env := newTestEnv(t, rules.KingdomConfig{Preset: "first_night"}, 0, "big_money")
+	if _, err := env.Reset(3); err != nil {
+		t.Fatal(err)
+	}
+	if hasAction(env.LegalActions(), CONFIRM) {
+		t.Error("expected no confirm without a decision")
+	}
+	if _, _, _, err := env.Step(CONFIRM); err == nil {
+		t.Error("expected confirm without a decision to fail")
+	}
+	if _, _, _, err := env.Step(env.ActionCount()); err == nil {
+		t.Error("expected an unknown action to fail")
+	}
+	if name := env.ActionName(env.action(buy_card, "reload")); name != "buy reload" {
+		t.Errorf("expected buy reload, got %q", name)
+	}
+}
+
+func TestPickingAnswersADecision(t *testing.T) {
+	env := newTestEnv(t, rules.KingdomConfig{Preset: "first_night"}, 0, "big_money")
+	if _, err := env.Reset(3); err != nil {
+		t.Fatal(err)
+	}
+	game := env.Game()
+	game.Players[0].Hand.Push(game.NewCard("cunning"))
+	if _, _, _, err := env.Step(env.action(play_card, "cunning")); err != nil {
+		t.Fatal(err)
+	}
+	decision := game.PendingDecision()
+	if decision == nil {
+		t.Fatal("expected cunning to ask what to trash")
+	}
+
+	legal := env.LegalActions()
+	picks := make([]int, 0)
+	for _, action := range legal {
+		if action >= card_actions {
+			if verb, _ := env.cardAction(action); verb == pick_card {
+				picks = append(picks, action)
+			}
+		}
+	}
+	if len(picks) == 0 || hasAction(legal, CONFIRM) != (decision.Min == 0) {
+		t.Fatalf("expected picks from the hand and confirm only if nothing has to be picked, got %v", legal)
+	}
+	if _, _, _, err := env.Step(picks[0]); err != nil {
+		t.Fatal(err)
+	}
+	legal = env.LegalActions()
+	if !hasAction(legal, CONFIRM) || hasAction(legal, picks[0]) && decision.Max == 1 {
+		t.Fatalf("expected to confirm one pick and pick no more, got %v", legal)
+	}
+	trashed := game.Trash.Len()
+	if _, _, _, err := env.Step(CONFIRM); err != nil {
+		t.Fatal(err)
+	}
+	if game.PendingDecision() != nil || game.Trash.Len() != trashed+1 {
+		t.Errorf("expected the pick to be trashed, trash has %d cards", game.Trash.Len())
+	}
+}
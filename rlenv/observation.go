@@ -0,0 +1,160 @@
+package rlenv
+
+import (
+	"github.com/quartermeat/card_game/gamestates"
+	"github.com/quartermeat/card_game/rules"
+)
+
+// scalars are the numbers at the start of an observation
+var scalars = []struct {
+	name  string
+	value func(env *Env) float64
+}{
+	{"my_turn", func(env *Env) float64 { return indicator(env.game.Current == env.seat) }},
+	{"phase_action", func(env *Env) float64 { return indicator(env.game.StateManager.GetPhase() == gamestates.ActionPhase) }},
+	{"phase_buy", func(env *Env) float64 { return indicator(env.game.StateManager.GetPhase() == gamestates.BuyPhase) }},
+	{"phase_cleanup", func(env *Env) float64 { return indicator(env.game.StateManager.GetPhase() == gamestates.CleanupPhase) }},
+	{"actions", func(env *Env) float64 { return float64(env.game.StateManager.GetActions()) }},
+	{"buys", func(env *Env) float64 { return float64(env.game.StateManager.GetBuys()) }},
+	{"coins", func(env *Env) float64 { return float64(env.game.StateManager.GetCoins()) }},
+	{"decision_cards", func(env *Env) float64 { return indicator(env.decisionKind() == rules.CHOOSE_CARDS) }},
+	{"decision_pile", func(env *Env) float64 { return indicator(env.decisionKind() == rules.CHOOSE_PILE) }},
+	{"decision_yes_no", func(env *Env) float64 { return indicator(env.decisionKind() == rules.YES_NO) }},
+	{"decision_min", func(env *Env) float64 {
+		if decision := env.game.PendingDecision(); decision != nil {
+			return float64(decision.Min)
+		}
+		return 0
+	}},
+	{"decision_max", func(env *Env) float64 {
+		if decision := env.game.PendingDecision(); decision != nil {
+			return float64(decision.Max)
+		}
+		return 0
+	}},
+	{"turn", func(env *Env) float64 { return float64(env.agentTurns()) }},
+	{"score", func(env *Env) float64 { return float64(env.game.Score(env.game.Players[env.seat])) }},
+	{"best_opponent_score", func(env *Env) float64 {
+		best, scored := 0, false
+		for seat, player := range env.game.Players {
+			if score := env.game.Score(player); seat != env.seat && (!scored || score > best) {
+				best, scored = score, true
+			}
+		}
+		return float64(best)
+	}},
+}
+
+// blocks are counts of each card, one number for every card in the order of Env.Cards
+var blocks = []struct {
+	name   string
+	counts func(env *Env) map[string]int
+}{
+	{"hand", func(env *Env) map[string]int { return countCards(env.game.Players[env.seat].Hand.Cards) }},
+	{"deck", func(env *Env) map[string]int { return countCards(env.game.Players[env.seat].Deck.Cards) }},
+	{"discard", func(env *Env) map[string]int { return countCards(env.game.Players[env.seat].Discard.Cards) }},
+	{"in_play", func(env *Env) map[string]int { return countCards(env.game.CurrentPlayer().InPlay.Cards) }},
+	{"offered", func(env *Env) map[string]int {
+		counts := make(map[string]int)
+		if decision := env.game.PendingDecision(); decision != nil {
+			for _, option := range decision.Options {
+				if option.Card != nil {
+					counts[option.Card.Name]++
+				} else {
+					counts[option.Name]++
+				}
+			}
+		}
+		return counts
+	}},
+	{"picked", func(env *Env) map[string]int {
+		counts := make(map[string]int)
+		for _, name := range env.picks {
+			counts[name]++
+		}
+		return counts
+	}},
+	{"in_supply", func(env *Env) map[string]int {
+		counts := make(map[string]int)
+		for _, pile := range env.game.Supply {
+			counts[pile.Name] = 1
+		}
+		return counts
+	}},
+	{"supply", func(env *Env) map[string]int {
+		counts := make(map[string]int)
+		for _, pile := range env.game.Supply {
+			counts[pile.Name] = pile.Len()
+		}
+		return counts
+	}},
+	{"opponents_own", func(env *Env) map[string]int {
+		cards := make([]*rules.Card, 0)
+		for seat, player := range env.game.Players {
+			if seat != env.seat {
+				cards = append(cards, player.Cards()...)
+			}
+		}
+		return countCards(cards)
+	}},
+	{"trash", func(env *Env) map[string]int { return countCards(env.game.Trash.Cards) }},
+}
+
+// Observe encodes what the agent can see as a fixed length list of numbers, named by ObservationNames:
+// the turn, its phase and counters, the pending decision and the scores, then for each card
+// the agent's hand, deck, discard, the cards in play, the decision's options and the agent's
+// picks, the supply and what the opponents own and the trash
+func (env *Env) Observe() []float64 {
+	observation := make([]float64, 0, env.ObservationSize())
+	for _, scalar := range scalars {
+		observation = append(observation, scalar.value(env))
+	}
+	for _, block := range blocks {
+		counts := block.counts(env)
+		for _, card := range env.Cards {
+			observation = append(observation, float64(counts[card]))
+		}
+	}
+	return observation
+}
+
+// ObservationNames names each number of an observation, like "coins" or "hand_reload"
+func (env *Env) ObservationNames() []string {
+	names := make([]string, 0, env.ObservationSize())
+	for _, scalar := range scalars {
+		names = append(names, scalar.name)
+	}
+	for _, block := range blocks {
+		for _, card := range env.Cards {
+			names = append(names, block.name+"_"+card)
+		}
+	}
+	return names
+}
+
+// ObservationSize is the length of every observation
+func (env *Env) ObservationSize() int {
+	return len(scalars) + len(blocks)*len(env.Cards)
+}
+
+func (env *Env) decisionKind() rules.DecisionKind {
+	if decision := env.game.PendingDecision(); decision != nil {
+		return decision.Kind
+	}
+	return ""
+}
+
+func countCards(cards []*rules.Card) map[string]int {
+	counts := make(map[string]int)
+	for _, card := range cards {
+		counts[card.Name]++
+	}
+	return counts
+}
+
+func indicator(set bool) float64 {
+	if set {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,55 @@
+package rlenv
+
+import (
+	"testing"
+
+	"github.com/quartermeat/card_game/rules"
+)
+
+func TestObservationNamesEachNumber(t *testing.T) {
+	env := newTestEnv(t, rules.KingdomConfig{Preset: "first_night"}, 0, "big_money", "random")
+	observation, err := env.Reset(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := env.ObservationNames()
+	if len(names) != env.ObservationSize() || len(observation) != env.ObservationSize() {
+		t.Fatalf("expected %d names and numbers, got %d and %d", env.ObservationSize(), len(names), len(observation))
+	}
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			t.Errorf("expected %s to name a single number", name)
+		}
+		seen[name] = true
+	}
+}
+
+func TestObservationCountsTheAgentsCards(t *testing.T) {
+	env := newTestEnv(t, rules.KingdomConfig{Preset: "first_night"}, 0, "big_money")
+	observation, err := env.Reset(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make(map[string]float64)
+	for index, name := range env.ObservationNames() {
+		values[name] = observation[index]
+	}
+	player := env.Game().Players[0]
+	hand := countCards(player.Hand.Cards)
+	if values["hand_bullet"] != float64(hand["bullet"]) || values["hand_slug"] != float64(hand["slug"]) {
+		t.Errorf("expected the hand %v, got %v bullets and %v slugs", hand, values["hand_bullet"], values["hand_slug"])
+	}
+	if values["hand_bullet"]+values["deck_bullet"] != 7 {
+		t.Errorf("expected 7 bullets between hand and deck, got %v", values["hand_bullet"]+values["deck_bullet"])
+	}
+	if values["my_turn"] != 1 || values["phase_action"] != 1 || values["actions"] != 1 || values["buys"] != 1 {
+		t.Errorf("expected the start of the agent's turn, got %v", values)
+	}
+	if values["in_supply_reload"] != 1 || values["supply_reload"] != 10 || values["in_supply_shotgun"] != 0 {
+		t.Errorf("expected first_night's supply, got reload %v of %v and shotgun %v", values["supply_reload"], values["in_supply_reload"], values["in_supply_shotgun"])
+	}
+	if values["opponents_own_bullet"] != 7 || values["opponents_own_zombies"] != 3 {
+		t.Errorf("expected the opponent's starting deck, got %v bullets and %v zombies", values["opponents_own_bullet"], values["opponents_own_zombies"])
+	}
+}
@@ -0,0 +1,146 @@
+package rlenv
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/quartermeat/card_game/rules"
+)
+
+// Request is a line of the JSON protocol. Cmd is "spec", "reset" with a Seed, "step" with an
+// Action, "observe" or "legal_actions".
+type Request struct {
+	Cmd    string `json:"cmd"`
+	Seed   int64  `json:"seed"`
+	Action int    `json:"action"`
+}
+
+// Spec answers "spec", describing the actions and observations of the environment
+type Spec struct {
+	ActionCount      int      `json:"action_count"`
+	ActionNames      []string `json:"action_names"`
+	ObservationSize  int      `json:"observation_size"`
+	ObservationNames []string `json:"observation_names"`
+}
+
+// State answers "reset", "step", "observe" and "legal_actions". Reward is the reward of the
+// step it answers, 0 for the others.
+type State struct {
+	Observation  []float64 `json:"observation"`
+	Reward       float64   `json:"reward"`
+	Done         bool      `json:"done"`
+	LegalActions []int     `json:"legal_actions"`
+	Seat         int       `json:"seat"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Serve reads a request from each line of reader and writes a response line to writer until
+// reader ends. A request that fails is answered with {"error": "..."} and the next one is read.
+func Serve(reader io.Reader, writer io.Writer, env *Env) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	encoder := json.NewEncoder(writer)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		response, err := env.handle(line)
+		if err != nil {
+			response = errorResponse{Error: err.Error()}
+		}
+		if err := encoder.Encode(response); err != nil {
+			return errors.Wrap(err, "error writing response")
+		}
+	}
+	return errors.Wrap(scanner.Err(), "error reading requests")
+}
+
+// handle answers one line of the protocol
+func (env *Env) handle(line string) (interface{}, error) {
+	var request Request
+	if err := json.Unmarshal([]byte(line), &request); err != nil {
+		return nil, errors.Wrap(err, "bad request")
+	}
+	if request.Cmd == "spec" {
+		names := make([]string, env.ActionCount())
+		for action := range names {
+			names[action] = env.ActionName(action)
+		}
+		return Spec{
+			ActionCount:      env.ActionCount(),
+			ActionNames:      names,
+			ObservationSize:  env.ObservationSize(),
+			ObservationNames: env.ObservationNames(),
+		}, nil
+	}
+
+	state := State{}
+	switch request.Cmd {
+	case "reset":
+		observation, err := env.Reset(request.Seed)
+		if err != nil {
+			return nil, err
+		}
+		state.Observation = observation
+	case "step":
+		observation, reward, done, err := env.Step(request.Action)
+		if err != nil {
+			return nil, err
+		}
+		state.Observation, state.Reward, state.Done = observation, reward, done
+	case "observe", "legal_actions":
+		if env.game == nil {
+			return nil, errors.New("no game yet, reset to start one")
+		}
+		state.Observation, state.Done = env.Observe(), env.done
+	default:
+		return nil, errors.Errorf("unknown cmd %q, expected spec, reset, step, observe or legal_actions", request.Cmd)
+	}
+	state.LegalActions = append([]int{}, env.LegalActions()...)
+	state.Seat = env.seat
+	return state, nil
+}
+
+// Main runs the env command with its command line arguments, serving the JSON protocol over
+// stdin and stdout
+func Main(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("env", flag.ContinueOnError)
+	var (
+		opponents = flags.String("opponents", "big_money", "comma separated strategies of the other seats, in turn order after the agent")
+		seat      = flags.Int("seat", 0, "seat of the agent, drawn from each game's seed if -1")
+		kingdom   = flags.String("kingdom", "", "kingdom preset or comma separated list of kingdom cards, a random kingdom each game if not set")
+		cards     = flags.String("cards", "assets/cards/cardDefinitions.csv", "card definitions file")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	definitions, err := rules.LoadCardDefinitions(*cards)
+	if err != nil {
+		return err
+	}
+	config := Config{
+		Definitions: definitions,
+		Kingdom:     rules.ParseKingdomConfig(*kingdom),
+		Seat:        *seat,
+	}
+	for _, name := range strings.Split(*opponents, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			config.Opponents = append(config.Opponents, name)
+		}
+	}
+	env, err := New(config)
+	if err != nil {
+		return err
+	}
+	return Serve(os.Stdin, stdout, env)
+}
@@ -0,0 +1,55 @@
+package rlenv
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/quartermeat/card_game/rules"
+)
+
+func TestServeAnswersEachLine(t *testing.T) {
+	env := newTestEnv(t, rules.KingdomConfig{Preset: "first_night"}, 0, "big_money")
+	requests := strings.Join([]string{
+		`{"cmd": "spec"}`,
+		`{"cmd": "step", "action": 0}`,
+		`{"cmd": "reset", "seed": 7}`,
+		``,
+		`{"cmd": "step", "action": 0}`,
+		`{"cmd": "legal_actions"}`,
+		`{"cmd": "fly"}`,
+		`not json`,
+	}, "\n")
+	var out bytes.Buffer
+	if err := Serve(strings.NewReader(requests), &out, env); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("expected a response for each request, got %q", lines)
+	}
+
+	var spec Spec
+	if err := json.Unmarshal([]byte(lines[0]), &spec); err != nil {
+		t.Fatal(err)
+	}
+	if spec.ActionCount != env.ActionCount() || len(spec.ActionNames) != spec.ActionCount || spec.ObservationSize != len(spec.ObservationNames) {
+		t.Errorf("expected a spec of the env, got %d actions and %d observation names", len(spec.ActionNames), len(spec.ObservationNames))
+	}
+	for _, index := range []int{1, 5, 6} {
+		var response errorResponse
+		if err := json.Unmarshal([]byte(lines[index]), &response); err != nil || response.Error == "" {
+			t.Errorf("expected an error, got %s", lines[index])
+		}
+	}
+	for _, index := range []int{2, 3, 4} {
+		var state State
+		if err := json.Unmarshal([]byte(lines[index]), &state); err != nil {
+			t.Fatal(err)
+		}
+		if len(state.Observation) != spec.ObservationSize || len(state.LegalActions) == 0 || state.Done {
+			t.Errorf("expected a state with an observation and legal actions, got %s", lines[index])
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package rules
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// CardType is one of the types printed on a card, a card can have several
+type CardType string
+
+const (
+	TREASURE CardType = "treasure"
+	VICTORY  CardType = "victory"
+	ACTION   CardType = "action"
+	ATTACK   CardType = "attack"
+	REACTION CardType = "reaction"
+	CURSE    CardType = "curse"
+)
+
+var cardTypes = []CardType{TREASURE, VICTORY, ACTION, ATTACK, REACTION, CURSE}
+
+// CardDefinition is the metadata shared by every copy of a card
+type CardDefinition struct {
+	Name  string
+	Cost  int
+	Types []CardType
+	Coins int
+	VP    int
+	// Text is the effects column of the definition file
+	Text    string
+	Effects []Effect
+}
+
+// Is returns true if the card has the card type
+func (def *CardDefinition) Is(cardType CardType) bool {
+	for _, defType := range def.Types {
+		if defType == cardType {
+			return true
+		}
+	}
+	return false
+}
+
+func isCardType(cardType CardType) bool {
+	for _, candidate := range cardTypes {
+		if candidate == cardType {
+			return true
+		}
+	}
+	return false
+}
+
+// CardDefinitions maps a card name to its definition
+type CardDefinitions map[string]*CardDefinition
+
+// Names returns the defined card names in alphabetical order
+func (defs CardDefinitions) Names() []string {
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadCardDefinitions reads the card definition file at path
+func LoadCardDefinitions(path string) (CardDefinitions, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading card definitions")
+	}
+	defer file.Close()
+
+	defs, err := ReadCardDefinitions(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading card definitions from %s", path)
+	}
+	return defs, nil
+}
+
+// ReadCardDefinitions reads card definitions in the csv format of assets/cards/cardDefinitions.csv
+func ReadCardDefinitions(reader io.Reader) (CardDefinitions, error) {
+	defs := make(CardDefinitions)
+
+	desc := csv.NewReader(reader)
+	desc.Comma = ','
+	desc.Comment = '#'
+	desc.FieldsPerRecord = 6
+	for {
+		record, err := desc.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		def, err := parseCardDefinition(record)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := defs[def.Name]; ok {
+			return nil, errors.Errorf("card %s is defined more than once", def.Name)
+		}
+		defs[def.Name] = def
+	}
+	if err := defs.checkNamedCards(); err != nil {
+		return nil, err
+	}
+	return defs, nil
+}
+
+// checkNamedCards makes sure every card named by an effect is defined
+func (defs CardDefinitions) checkNamedCards() error {
+	for _, name := range defs.Names() {
+		for _, effect := range defs[name].Effects {
+			if effect.Inner != nil {
+				effect = *effect.Inner
+			}
+			if effect.Card != "" && defs[effect.Card] == nil {
+				return errors.Errorf("card %s names undefined card %s", name, effect.Card)
+			}
+		}
+	}
+	return nil
+}
+
+func parseCardDefinition(record []string) (*CardDefinition, error) {
+	def := &CardDefinition{
+		Name: strings.TrimSpace(record[0]),
+		Text: strings.TrimSpace(record[5]),
+	}
+	if def.Name == "" {
+		return nil, errors.New("card definition without a name")
+	}
+
+	var err error
+	if def.Cost, err = strconv.Atoi(strings.TrimSpace(record[1])); err != nil {
+		return nil, errors.Wrapf(err, "cost of %s", def.Name)
+	}
+	if def.Coins, err = strconv.Atoi(strings.TrimSpace(record[3])); err != nil {
+		return nil, errors.Wrapf(err, "coins of %s", def.Name)
+	}
+	if def.VP, err = strconv.Atoi(strings.TrimSpace(record[4])); err != nil {
+		return nil, errors.Wrapf(err, "victory points of %s", def.Name)
+	}
+
+	if def.Effects, err = ParseEffects(def.Text); err != nil {
+		return nil, errors.Wrapf(err, "effects of %s", def.Name)
+	}
+
+	for _, typeName := range strings.Split(record[2], "|") {
+		cardType := CardType(strings.TrimSpace(typeName))
+		if !isCardType(cardType) {
+			return nil, errors.Errorf("card %s has unknown type %q", def.Name, cardType)
+		}
+		def.Types = append(def.Types, cardType)
+	}
+	for _, effect := range def.Effects {
+		if effect.Verb == REACT && !def.Is(REACTION) {
+			return nil, errors.Errorf("card %s reacts but isn't a reaction", def.Name)
+		}
+	}
+	return def, nil
+}
+
+// CheckArt makes sure every defined card has art and every piece of card art has a definition
+func (defs CardDefinitions) CheckArt(artNames []string) error {
+	problems := make([]string, 0)
+	hasArt := make(map[string]bool)
+	for _, name := range artNames {
+		hasArt[name] = true
+		if _, ok := defs[name]; !ok {
+			problems = append(problems, name+" has art but no definition")
+		}
+	}
+	for _, name := range defs.Names() {
+		if !hasArt[name] {
+			problems = append(problems, name+" has a definition but no art")
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return errors.Errorf("card definitions do not match card art: %s", strings.Join(problems, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDefinitionsCSV = `# name,cost,types,coins,vp,effects
+bullet,0,treasure,1,0,
+slug,3,treasure,2,0,
+zombies,2,victory,0,1,
+infection,0,curse,0,-1,
+barricade,2,action|reaction,0,0,"+2 cards; react block"
+decoy,3,action|reaction,0,0,"+2 coins; react +1 card"
+reload,4,action,0,0,+3 cards
+scavenger,4,action,0,0,"trash 1; gain 4"
+molotov_cocktail,4,action|attack,0,0,"+2 coins; others discard_to 3"
+zombie_swarm,5,action|attack,0,0,"+2 cards; others gain infection"
+`
+
+func testDefinitions(t *testing.T) CardDefinitions {
+	t.Helper()
+	defs, err := ReadCardDefinitions(strings.NewReader(testDefinitionsCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return defs
+}
+
+func TestReadCardDefinitions(t *testing.T) {
+	defs := testDefinitions(t)
+
+	slug := defs["slug"]
+	if slug == nil || slug.Cost != 3 || slug.Coins != 2 || !slug.Is(TREASURE) {
+		t.Errorf("unexpected slug definition: %+v", slug)
+	}
+	barricade := defs["barricade"]
+	if barricade == nil || !barricade.Is(ACTION) || !barricade.Is(REACTION) || barricade.Is(ATTACK) {
+		t.Errorf("unexpected barricade definition: %+v", barricade)
+	}
+	if defs["infection"].VP != -1 {
+		t.Errorf("expected infection to be worth -1, got %d", defs["infection"].VP)
+	}
+}
+
+func TestReadCardDefinitionsRejectsBadRows(t *testing.T) {
+	for _, row := range []string{
+		"bullet,zero,treasure,1,0,\n",
+		"bullet,0,money,1,0,\n",
+		"bullet,0,treasure,1,0,\nbullet,0,treasure,1,0,\n",
+		"bullet,0,treasure,1\n",
+		"bullet,0,treasure,1,0,+1 zombie\n",
+		"witch,5,action,0,0,gain curse\n",
+	} {
+		if defs, err := ReadCardDefinitions(strings.NewReader(row)); err == nil || defs != nil {
+			t.Errorf("expected only an error reading %q, got %v", row, defs)
+		}
+	}
+}
+
+func TestCheckArt(t *testing.T) {
+	defs := testDefinitions(t)
+	if err := defs.CheckArt(defs.Names()); err != nil {
+		t.Errorf("expected matching art to pass: %s", err)
+	}
+
+	err := defs.CheckArt([]string{"bullet", "slug", "zombies", "infection", "reload", "scavenger", "molotov_cocktail", "zombie_swarm", "shotgun"})
+	if err == nil {
+		t.Fatal("expected mismatched art to fail")
+	}
+	for _, problem := range []string{"shotgun has art but no definition", "barricade has a definition but no art"} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("expected %q in %q", problem, err)
+		}
+	}
+}
+
+func TestShippedCardDefinitionsLoad(t *testing.T) {
+	defs, err := LoadCardDefinitions("../assets/cards/cardDefinitions.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(defs) != 33 {
+		t.Errorf("expected 33 card definitions, got %d", len(defs))
+	}
+}
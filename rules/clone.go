@@ -0,0 +1,51 @@
+package rules
+
+import "math/rand"
+
+// Clone returns a copy of the game that can be played on without changing the original.
+// Cards are shared, as they never change, but every pile is copied. The copy draws its
+// randomness from seed and has no deciders, so its decisions wait for Answer.
+func (game *GameState) Clone(seed int64) *GameState {
+	piles := make(map[*Pile]*Pile)
+	clonePile := func(pile *Pile) *Pile {
+		clone := &Pile{Name: pile.Name, Cards: append([]*Card(nil), pile.Cards...)}
+		piles[pile] = clone
+		return clone
+	}
+
+	clone := *game
+	clone.StateManager = game.StateManager.Clone()
+	clone.Rand = rand.New(rand.NewSource(seed))
+	clone.Kingdom = append([]string(nil), game.Kingdom...)
+	clone.Trash = clonePile(game.Trash)
+	clone.Supply = make([]*Pile, 0, len(game.Supply))
+	for _, pile := range game.Supply {
+		clone.Supply = append(clone.Supply, clonePile(pile))
+	}
+	clone.Players = make([]*Player, 0, len(game.Players))
+	for _, player := range game.Players {
+		copied := *player
+		copied.Deck = clonePile(player.Deck)
+		copied.Hand = clonePile(player.Hand)
+		copied.Discard = clonePile(player.Discard)
+		copied.InPlay = clonePile(player.InPlay)
+		clone.Players = append(clone.Players, &copied)
+	}
+	clone.queue = append([]pendingEffect(nil), game.queue...)
+	clone.deciders = nil
+	clone.unaffected = make(map[int]bool, len(game.unaffected))
+	for seat, unaffected := range game.unaffected {
+		clone.unaffected[seat] = unaffected
+	}
+	if game.decision != nil {
+		decision := *game.decision
+		decision.Options = append([]Option(nil), game.decision.Options...)
+		for index, option := range decision.Options {
+			if option.Pile != nil {
+				decision.Options[index].Pile = piles[option.Pile]
+			}
+		}
+		clone.decision = &decision
+	}
+	return &clone
+}
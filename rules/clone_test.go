@@ -0,0 +1,53 @@
+package rules
+
+import "testing"
+
+func TestCloneLeavesOriginalAlone(t *testing.T) {
+	game := newTestGame(t)
+	player := game.Players[0]
+	if err := game.PlayAction(player, giveCard(game, player, "scavenger")); err != nil {
+		t.Fatal(err)
+	}
+	hand := player.Hand.Len()
+
+	clone := game.Clone(2)
+	if clone.PendingDecision() == nil || clone.Chooser() != 0 {
+		t.Fatal("expected the clone to be waiting on the same decision")
+	}
+	if err := clone.Answer([]int{0}); err != nil {
+		t.Fatal(err)
+	}
+	gain := clone.PendingDecision()
+	if gain == nil || gain.Options[0].Pile != clone.SupplyPile(gain.Options[0].Name) {
+		t.Fatalf("expected the clone's gain to offer its own supply piles, got %+v", gain)
+	}
+	supply := game.SupplyPile(gain.Options[0].Name).Len()
+	if err := clone.Answer([]int{0}); err != nil {
+		t.Fatal(err)
+	}
+	clone.StateManager.AddCoins(5)
+
+	if game.PendingDecision() == nil || player.Hand.Len() != hand || game.Trash.Len() != 0 {
+		t.Error("expected answering on the clone to leave the original's decision and hand alone")
+	}
+	if game.SupplyPile(gain.Options[0].Name).Len() != supply || player.Discard.Len() != 0 {
+		t.Error("expected gaining on the clone to leave the original's supply alone")
+	}
+	if game.StateManager.GetCoins() != 0 {
+		t.Error("expected the clone to have its own state manager")
+	}
+}
+
+func TestCloneHasNoDeciders(t *testing.T) {
+	game := newTestGame(t)
+	game.SetDecider(0, FirstOptions)
+	clone := game.Clone(1)
+	player := clone.Players[0]
+
+	if err := clone.PlayAction(player, giveCard(clone, player, "scavenger")); err != nil {
+		t.Fatal(err)
+	}
+	if clone.PendingDecision() == nil {
+		t.Error("expected the clone's decision to wait for an answer")
+	}
+}
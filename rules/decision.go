@@ -0,0 +1,198 @@
+package rules
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// DecisionKind says what the options of a decision are
+type DecisionKind string
+
+const (
+	// CHOOSE_CARDS picks cards, usually from the deciding player's hand
+	CHOOSE_CARDS DecisionKind = "cards"
+	// CHOOSE_PILE picks a supply pile
+	CHOOSE_PILE DecisionKind = "pile"
+	// YES_NO picks exactly one of the options YES and NO
+	YES_NO DecisionKind = "yes_no"
+)
+
+// the options of a YES_NO decision
+const (
+	YES = iota
+	NO
+)
+
+// Option is one of the choices of a decision
+type Option struct {
+	Name string
+	// Card is the card picked, nil when the option isn't a card
+	Card *Card
+	// Pile is the supply pile picked, nil when the option isn't a supply pile
+	Pile *Pile
+}
+
+// Decision is a choice the engine waits on before it carries on resolving effects.
+// It is the same whether a person or a bot makes it, the answer is the indexes of between
+// Min and Max of the Options.
+type Decision struct {
+	Kind    DecisionKind
+	Player  int
+	Prompt  string
+	Options []Option
+	Min     int
+	Max     int
+	effect  pendingEffect
+}
+
+// Verb returns what the decision is for, like TRASH_CARDS or DISCARD_TO
+func (decision *Decision) Verb() Verb {
+	return decision.effect.effect.Verb
+}
+
+// Decider answers decisions for a seat with the indexes of the options it picks
+type Decider interface {
+	Decide(game *GameState, decision *Decision) []int
+}
+
+// DeciderFunc lets a function be used as a Decider
+type DeciderFunc func(game *GameState, decision *Decision) []int
+
+// Decide calls the function
+func (decide DeciderFunc) Decide(game *GameState, decision *Decision) []int {
+	return decide(game, decision)
+}
+
+// FirstOptions is a Decider that picks as few options as it is allowed to, taking them from the start
+var FirstOptions = DeciderFunc(func(game *GameState, decision *Decision) []int {
+	picks := make([]int, decision.Min)
+	for pick := range picks {
+		picks[pick] = pick
+	}
+	return picks
+})
+
+// SetDecider has decider answer every decision of a seat as soon as it comes up,
+// seats without a decider leave their decisions pending until Answer is called
+func (game *GameState) SetDecider(seat int, decider Decider) {
+	if game.deciders == nil {
+		game.deciders = make(map[int]Decider)
+	}
+	if decider == nil {
+		delete(game.deciders, seat)
+		return
+	}
+	game.deciders[seat] = decider
+}
+
+// Decider returns the decider of a seat, nil if the seat answers its own decisions
+func (game *GameState) Decider(seat int) Decider {
+	return game.deciders[seat]
+}
+
+// PendingDecision returns the decision the engine is waiting on, nil if it isn't waiting
+func (game *GameState) PendingDecision() *Decision {
+	return game.decision
+}
+
+// Answer answers the pending decision with the indexes of the picked options
+// and carries on resolving effects
+func (game *GameState) Answer(picks []int) error {
+	decision := game.decision
+	if decision == nil {
+		return ErrNoDecision
+	}
+	if err := decision.Check(picks); err != nil {
+		return err
+	}
+
+	game.decision = nil
+	game.complete(decision, picks)
+	game.resolve()
+	return nil
+}
+
+// Check returns an error if picks isn't a valid answer to the decision
+func (decision *Decision) Check(picks []int) error {
+	if len(picks) < decision.Min || len(picks) > decision.Max {
+		return errors.Errorf("pick between %d and %d options, not %d", decision.Min, decision.Max, len(picks))
+	}
+	picked := make(map[int]bool)
+	for _, pick := range picks {
+		if pick < 0 || pick >= len(decision.Options) || picked[pick] {
+			return errors.Errorf("invalid pick %d", pick)
+		}
+		picked[pick] = true
+	}
+	return nil
+}
+
+// autoAnswer answers the pending decision with the deciding seat's decider, false if the seat
+// has no decider. A decider that gives an invalid answer gets the FirstOptions answer instead.
+func (game *GameState) autoAnswer() bool {
+	decision := game.decision
+	decider := game.deciders[decision.Player]
+	if decider == nil {
+		return false
+	}
+	picks := decider.Decide(game, decision)
+	if decision.Check(picks) != nil {
+		picks = FirstOptions(game, decision)
+	}
+	sort.Ints(picks)
+
+	game.decision = nil
+	game.complete(decision, picks)
+	return true
+}
+
+// decide leaves a decision pending, unless there is nothing to choose
+func (game *GameState) decide(pending pendingEffect, kind DecisionKind, prompt string, options []Option, min int, max int) {
+	if max > len(options) {
+		max = len(options)
+	}
+	if min > max {
+		min = max
+	}
+	if max <= 0 {
+		return
+	}
+	game.decision = &Decision{
+		Kind:    kind,
+		Player:  pending.player,
+		Prompt:  prompt,
+		Options: options,
+		Min:     min,
+		Max:     max,
+		effect:  pending,
+	}
+}
+
+// complete applies the answer to a decision
+func (game *GameState) complete(decision *Decision, picks []int) {
+	player := game.Players[decision.Player]
+	for _, pick := range picks {
+		option := decision.Options[pick]
+		switch decision.effect.effect.Verb {
+		case GAIN:
+			game.Gain(player, option.Pile)
+		case TRASH_CARDS:
+			MoveCard(option.Card, player.Hand, game.Trash)
+		case DISCARD_TO:
+			MoveCard(option.Card, player.Hand, player.Discard)
+		case reveal:
+			if pick == YES {
+				game.react(decision.effect)
+			}
+		}
+	}
+}
+
+func handOptions(player *Player) []Option {
+	options := make([]Option, 0, player.Hand.Len())
+	for _, card := range player.Hand.Cards {
+		options = append(options, Option{Name: card.Name, Card: card})
+	}
+	return options
+}
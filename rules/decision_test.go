@@ -0,0 +1,64 @@
+package rules
+
+import "testing"
+
+func TestDeciderAnswersForItsSeat(t *testing.T) {
+	game := newTestGame(t)
+	player, ai := game.Players[0], game.Players[1]
+	asked := 0
+	game.SetDecider(1, DeciderFunc(func(game *GameState, decision *Decision) []int {
+		asked++
+		if decision.Kind != CHOOSE_CARDS || decision.Player != 1 {
+			t.Errorf("unexpected decision %+v", decision)
+		}
+		return []int{3, 4}
+	}))
+
+	kept := ai.Hand.Cards[:3]
+	if err := game.PlayAction(player, giveCard(game, player, "molotov_cocktail")); err != nil {
+		t.Fatal(err)
+	}
+	if asked != 1 || game.PendingDecision() != nil {
+		t.Fatalf("expected the decider to answer straight away, asked %d times", asked)
+	}
+	if ai.Hand.Len() != 3 || ai.Hand.Cards[0] != kept[0] || ai.Hand.Cards[2] != kept[2] {
+		t.Error("expected the ai to discard the cards it picked")
+	}
+
+	// the player's own decisions still wait for an answer
+	game.StateManager.AddActions(1)
+	game.PlayAction(player, giveCard(game, player, "scavenger"))
+	if decision := game.PendingDecision(); decision == nil || decision.Player != 0 {
+		t.Fatalf("expected the player's decision to wait, got %+v", decision)
+	}
+}
+
+func TestInvalidDeciderAnswerFallsBack(t *testing.T) {
+	game := newTestGame(t)
+	player, ai := game.Players[0], game.Players[1]
+	game.SetDecider(1, DeciderFunc(func(game *GameState, decision *Decision) []int {
+		return []int{0, 0, 9}
+	}))
+
+	game.PlayAction(player, giveCard(game, player, "molotov_cocktail"))
+	if game.PendingDecision() != nil || ai.Hand.Len() != 3 {
+		t.Errorf("expected the first options to be discarded instead, %d cards in hand", ai.Hand.Len())
+	}
+
+	game.SetDecider(1, nil)
+	if game.Decider(1) != nil {
+		t.Error("expected the decider to be removed")
+	}
+}
+
+func TestDecisionCheck(t *testing.T) {
+	decision := &Decision{Kind: YES_NO, Options: []Option{{Name: "yes"}, {Name: "no"}}, Min: 1, Max: 1}
+	if err := decision.Check([]int{NO}); err != nil {
+		t.Error(err)
+	}
+	for _, picks := range [][]int{{}, {YES, NO}, {2}, {-1}} {
+		if decision.Check(picks) == nil {
+			t.Errorf("expected %v to be rejected", picks)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Verb is what an effect does
+type Verb string
+
+const (
+	// +N cards, +N actions, +N buys and +N coins
+	DRAW_CARDS  Verb = "cards"
+	ADD_ACTIONS Verb = "actions"
+	ADD_BUYS    Verb = "buys"
+	ADD_COINS   Verb = "coins"
+	// gain a card costing up to N, or gain a named card
+	GAIN Verb = "gain"
+	// trash up to N cards from hand
+	TRASH_CARDS Verb = "trash"
+	// discard down to N cards in hand
+	DISCARD_TO Verb = "discard_to"
+	// each other player resolves the inner effect
+	OTHERS Verb = "others"
+	// revealing the reaction from hand when attacked resolves the inner effect before the attack
+	REACT Verb = "react"
+	// the inner effect of 'react block', the attack has no effect on the player who reacted
+	BLOCK Verb = "block"
+	// reveal asks the player to reveal a reaction, it is only queued by the engine
+	reveal Verb = "reveal"
+)
+
+// Effect is one step of what a card does when it is played.
+// Card definitions list effects in their effects column separated by ';', for example
+//
+//	+2 cards; +1 action; gain 4; trash 2; others gain infection; others discard_to 3; react block; +1 coin per action
+type Effect struct {
+	Verb   Verb
+	Amount int
+	// Card is the named card of 'gain <card>'
+	Card string
+	// Per is the card type of '+N <noun> per <type>', the amount is given for each card of
+	// the type the player has in play, counting the card itself
+	Per CardType
+	// Inner is the effect each other player resolves for 'others', or the reaction of 'react'
+	Inner *Effect
+}
+
+func (effect Effect) String() string {
+	switch effect.Verb {
+	case DRAW_CARDS, ADD_ACTIONS, ADD_BUYS, ADD_COINS:
+		if effect.Per != "" {
+			return fmt.Sprintf("+%d %s per %s", effect.Amount, effect.Verb, effect.Per)
+		}
+		return fmt.Sprintf("+%d %s", effect.Amount, effect.Verb)
+	case GAIN:
+		if effect.Card != "" {
+			return fmt.Sprintf("%s %s", effect.Verb, effect.Card)
+		}
+		return fmt.Sprintf("%s %d", effect.Verb, effect.Amount)
+	case OTHERS, REACT:
+		return fmt.Sprintf("%s %s", effect.Verb, effect.Inner)
+	case BLOCK, reveal:
+		return string(effect.Verb)
+	}
+	return fmt.Sprintf("%s %d", effect.Verb, effect.Amount)
+}
+
+// counterVerbs maps the nouns of '+N <noun>' to their verb, singular or plural
+var counterVerbs = map[string]Verb{
+	"card":    DRAW_CARDS,
+	"cards":   DRAW_CARDS,
+	"action":  ADD_ACTIONS,
+	"actions": ADD_ACTIONS,
+	"buy":     ADD_BUYS,
+	"buys":    ADD_BUYS,
+	"coin":    ADD_COINS,
+	"coins":   ADD_COINS,
+}
+
+// ParseEffects parses the effects column of a card definition
+func ParseEffects(text string) ([]Effect, error) {
+	effects := make([]Effect, 0)
+	for _, part := range strings.Split(text, ";") {
+		words := strings.Fields(part)
+		if len(words) == 0 {
+			continue
+		}
+		effect, err := parseEffect(words)
+		if err != nil {
+			return nil, errors.Wrapf(err, "effect %q", strings.TrimSpace(part))
+		}
+		effects = append(effects, effect)
+	}
+	return effects, nil
+}
+
+func parseEffect(words []string) (Effect, error) {
+	if len(words) < 2 {
+		return Effect{}, errors.New("expected a verb and an amount")
+	}
+
+	if strings.HasPrefix(words[0], "+") {
+		verb, ok := counterVerbs[words[1]]
+		if !ok || (len(words) != 2 && len(words) != 4) {
+			return Effect{}, errors.Errorf("unknown counter %q", strings.Join(words[1:], " "))
+		}
+		amount, err := parseAmount(strings.TrimPrefix(words[0], "+"))
+		effect := Effect{Verb: verb, Amount: amount}
+		if len(words) == 4 {
+			if words[2] != "per" || !isCardType(CardType(words[3])) {
+				return Effect{}, errors.Errorf("expected per and a card type, got %q", strings.Join(words[2:], " "))
+			}
+			effect.Per = CardType(words[3])
+		}
+		return effect, err
+	}
+
+	switch Verb(words[0]) {
+	case OTHERS:
+		inner, err := parseEffect(words[1:])
+		if err != nil {
+			return Effect{}, err
+		}
+		if inner.Verb == OTHERS || inner.Verb == REACT {
+			return Effect{}, errors.New("others can't be nested")
+		}
+		return Effect{Verb: OTHERS, Inner: &inner}, nil
+	case REACT:
+		if len(words) == 2 && Verb(words[1]) == BLOCK {
+			return Effect{Verb: REACT, Inner: &Effect{Verb: BLOCK}}, nil
+		}
+		inner, err := parseEffect(words[1:])
+		if err != nil {
+			return Effect{}, err
+		}
+		if inner.Verb == OTHERS || inner.Verb == REACT {
+			return Effect{}, errors.New("a reaction can't be nested")
+		}
+		return Effect{Verb: REACT, Inner: &inner}, nil
+	case GAIN:
+		if len(words) != 2 {
+			break
+		}
+		if amount, err := parseAmount(words[1]); err == nil {
+			return Effect{Verb: GAIN, Amount: amount}, nil
+		}
+		return Effect{Verb: GAIN, Card: words[1]}, nil
+	case TRASH_CARDS, DISCARD_TO:
+		if len(words) != 2 {
+			break
+		}
+		amount, err := parseAmount(words[1])
+		return Effect{Verb: Verb(words[0]), Amount: amount}, err
+	}
+	return Effect{}, errors.Errorf("unknown effect %q", strings.Join(words, " "))
+}
+
+func parseAmount(word string) (int, error) {
+	amount, err := strconv.Atoi(word)
+	if err != nil {
+		return 0, errors.Errorf("%q is not an amount", word)
+	}
+	if amount < 0 {
+		return 0, errors.Errorf("amount %d is negative", amount)
+	}
+	return amount, nil
+}
@@ -0,0 +1,45 @@
+package rules
+
+import "testing"
+
+func TestParseEffects(t *testing.T) {
+	effects, err := ParseEffects("+2 cards; +1 action ;gain 4; gain infection; trash 2; others discard_to 3; others +1 card; react block; react +1 card; +1 coin per action")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Effect{
+		{Verb: DRAW_CARDS, Amount: 2},
+		{Verb: ADD_ACTIONS, Amount: 1},
+		{Verb: GAIN, Amount: 4},
+		{Verb: GAIN, Card: "infection"},
+		{Verb: TRASH_CARDS, Amount: 2},
+		{Verb: OTHERS, Inner: &Effect{Verb: DISCARD_TO, Amount: 3}},
+		{Verb: OTHERS, Inner: &Effect{Verb: DRAW_CARDS, Amount: 1}},
+		{Verb: REACT, Inner: &Effect{Verb: BLOCK}},
+		{Verb: REACT, Inner: &Effect{Verb: DRAW_CARDS, Amount: 1}},
+		{Verb: ADD_COINS, Amount: 1, Per: ACTION},
+	}
+	if len(effects) != len(expected) {
+		t.Fatalf("expected %d effects, got %d", len(expected), len(effects))
+	}
+	for i, effect := range effects {
+		if effect.String() != expected[i].String() {
+			t.Errorf("effect %d: expected %s, got %s", i, expected[i], effect)
+		}
+	}
+}
+
+func TestParseEffectsRejectsUnknown(t *testing.T) {
+	for _, text := range []string{"+2 zombies", "+x cards", "gain", "trash all", "others others +1 card", "shuffle 1", "+-1 cards", "block", "react", "react react block", "others react block", "react block 2", "+1 coin per zombie", "+1 coin for action", "+1 coin per"} {
+		if _, err := ParseEffects(text); err == nil {
+			t.Errorf("expected an error parsing %q", text)
+		}
+	}
+}
+
+func TestParseEffectsEmpty(t *testing.T) {
+	effects, err := ParseEffects("")
+	if err != nil || len(effects) != 0 {
+		t.Errorf("expected no effects, got %v %v", effects, err)
+	}
+}
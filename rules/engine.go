@@ -0,0 +1,352 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/quartermeat/card_game/gamestates"
+)
+
+var (
+	// ErrDecisionPending is returned when a move is made while the engine is waiting on a decision
+	ErrDecisionPending = errors.New("a decision is waiting to be answered")
+	// ErrNoDecision is returned when answering while no decision is waiting
+	ErrNoDecision = errors.New("no decision is waiting to be answered")
+	// ErrNotInHand is returned when playing a card the player isn't holding
+	ErrNotInHand = errors.New("card is not in hand")
+	// ErrNotAnAction is returned when playing a card that isn't an action in the action phase
+	ErrNotAnAction = errors.New("card is not an action")
+	// ErrNoActions is returned when playing an action without an action left
+	ErrNoActions = errors.New("no actions left")
+	// ErrNotPlaying is returned for a player who isn't seated in the game
+	ErrNotPlaying = errors.New("player is not in the game")
+	// ErrNotYourTurn is returned when a player makes a move on someone else's turn
+	ErrNotYourTurn = errors.New("it is not this player's turn")
+	// ErrNotATreasure is returned when playing a card for coins that isn't a treasure
+	ErrNotATreasure = errors.New("card is not a treasure")
+	// ErrWrongPhase is returned for a move the current phase doesn't allow
+	ErrWrongPhase = errors.New("not allowed in this phase")
+	// ErrEmptyPile is returned when buying from a supply pile with no cards left
+	ErrEmptyPile = errors.New("supply pile is empty")
+	// ErrCantAfford is returned when buying without a buy or enough coins left
+	ErrCantAfford = errors.New("not enough coins or buys")
+)
+
+// pendingEffect is an effect queued for a player to resolve
+type pendingEffect struct {
+	player int
+	effect Effect
+	// source is the card whose effect it is
+	source *Card
+	// reaction is the card a reveal asks about
+	reaction *Card
+}
+
+// CurrentPlayer returns the player taking their turn
+func (game *GameState) CurrentPlayer() *Player {
+	return game.Players[game.Current]
+}
+
+// Start seats the players with the state manager, deals every player their opening hand from
+// their own deck and starts the first player's turn
+func (game *GameState) Start() {
+	seats := make([]gamestates.State, 0, len(game.Players))
+	for _, player := range game.Players {
+		if player.Human {
+			seats = append(seats, gamestates.PlayerTurn)
+		} else {
+			seats = append(seats, gamestates.AiTurn)
+		}
+		game.Draw(player, HAND_SIZE)
+	}
+	game.StateManager.SetSeats(seats...)
+	game.Current = 0
+	game.startTurn()
+}
+
+// startTurn starts the current player's turn
+func (game *GameState) startTurn() {
+	game.CurrentPlayer().Turns++
+	game.StateManager.StartTurn(game.Current)
+}
+
+// EndPhase ends the current phase of the turn. Entering cleanup discards the hand and the cards
+// in play and draws a new hand, ending cleanup passes the turn to the next player or ends the game.
+func (game *GameState) EndPhase() (gamestates.Phase, error) {
+	if game.decision != nil {
+		return game.StateManager.GetPhase(), ErrDecisionPending
+	}
+	if !game.StateManager.IsTurn() {
+		return game.StateManager.GetPhase(), ErrNotYourTurn
+	}
+
+	if game.StateManager.GetPhase() == gamestates.CleanupPhase {
+		game.endTurn()
+		return game.StateManager.GetPhase(), nil
+	}
+
+	phase := game.StateManager.EndPhase()
+	if phase == gamestates.CleanupPhase {
+		game.cleanup(game.CurrentPlayer())
+	}
+	return phase, nil
+}
+
+// cleanup discards a player's hand and the cards they have in play, then draws a new hand
+func (game *GameState) cleanup(player *Player) {
+	player.Discard.Push(player.InPlay.Cards...)
+	player.InPlay.Cards = player.InPlay.Cards[:0]
+	player.Discard.Push(player.Hand.Cards...)
+	player.Hand.Cards = player.Hand.Cards[:0]
+	game.Draw(player, HAND_SIZE)
+}
+
+// PlayerIndex returns the seat of a player in the game, -1 if they aren't playing
+func (game *GameState) PlayerIndex(player *Player) int {
+	for index, candidate := range game.Players {
+		if candidate == player {
+			return index
+		}
+	}
+	return -1
+}
+
+// PlayAction plays an action card from a player's hand and resolves its effects
+// until they are done or a decision is needed
+func (game *GameState) PlayAction(player *Player, card *Card) error {
+	index, err := game.checkTurn(player)
+	if err != nil {
+		return err
+	}
+	def := game.Definition(card)
+	if def == nil || !def.Is(ACTION) {
+		return ErrNotAnAction
+	}
+	if !player.Hand.Remove(card) {
+		return ErrNotInHand
+	}
+	if !game.StateManager.UseAction() {
+		player.Hand.Push(card)
+		return ErrNoActions
+	}
+	player.InPlay.Push(card)
+
+	queued := make([]pendingEffect, 0, len(def.Effects))
+	if def.Is(ATTACK) {
+		queued = append(queued, game.reactionWindow(index, card)...)
+	}
+	for _, effect := range def.Effects {
+		queued = append(queued, pendingEffect{player: index, effect: effect, source: card})
+	}
+	game.queue = append(queued, game.queue...)
+	game.resolve()
+	return nil
+}
+
+// Play plays a card from a player's hand, treasures for coins and actions for their effects
+func (game *GameState) Play(player *Player, card *Card) error {
+	if def := game.Definition(card); def != nil && def.Is(TREASURE) {
+		return game.PlayTreasure(player, card)
+	}
+	return game.PlayAction(player, card)
+}
+
+// PlayTreasure plays a treasure from a player's hand for its coins, ending the action phase if needed
+func (game *GameState) PlayTreasure(player *Player, card *Card) error {
+	if _, err := game.checkTurn(player); err != nil {
+		return err
+	}
+	def := game.Definition(card)
+	if def == nil || !def.Is(TREASURE) {
+		return ErrNotATreasure
+	}
+	if err := game.checkBuyPhase(); err != nil {
+		return err
+	}
+	if !player.Hand.Contains(card) {
+		return ErrNotInHand
+	}
+	game.startBuyPhase()
+	MoveCard(card, player.Hand, player.InPlay)
+	game.StateManager.AddCoins(def.Coins)
+	return nil
+}
+
+// PlayAllTreasures plays every treasure in a player's hand
+func (game *GameState) PlayAllTreasures(player *Player) error {
+	treasures := make([]*Card, 0)
+	for _, card := range player.Hand.Cards {
+		if def := game.Definition(card); def != nil && def.Is(TREASURE) {
+			treasures = append(treasures, card)
+		}
+	}
+	for _, card := range treasures {
+		if err := game.PlayTreasure(player, card); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Buy buys the top card of a supply pile into a player's discard pile, ending the action phase if needed
+func (game *GameState) Buy(player *Player, pile *Pile) error {
+	if _, err := game.checkTurn(player); err != nil {
+		return err
+	}
+	if pile.Len() == 0 {
+		return ErrEmptyPile
+	}
+	if err := game.checkBuyPhase(); err != nil {
+		return err
+	}
+	cost := game.Definition(pile.Top()).Cost
+	if game.StateManager.GetBuys() == 0 || game.StateManager.GetCoins() < cost {
+		return ErrCantAfford
+	}
+	game.startBuyPhase()
+	game.StateManager.UseBuy(cost)
+	game.Gain(player, pile)
+	return nil
+}
+
+// checkTurn makes sure it's the player's turn and nothing is waiting to be decided, returning their seat
+func (game *GameState) checkTurn(player *Player) (int, error) {
+	if game.decision != nil {
+		return -1, ErrDecisionPending
+	}
+	index := game.PlayerIndex(player)
+	if index == -1 {
+		return index, ErrNotPlaying
+	}
+	if index != game.Current || !game.StateManager.IsTurn() {
+		return index, ErrNotYourTurn
+	}
+	return index, nil
+}
+
+// checkBuyPhase makes sure the buy phase has started or can start, buying isn't allowed in cleanup
+func (game *GameState) checkBuyPhase() error {
+	if game.StateManager.GetPhase() == gamestates.CleanupPhase {
+		return ErrWrongPhase
+	}
+	return nil
+}
+
+// startBuyPhase moves an action phase on to the buy phase, once a treasure or buy is known to succeed
+func (game *GameState) startBuyPhase() {
+	if game.StateManager.GetPhase() == gamestates.ActionPhase {
+		game.StateManager.EndPhase()
+	}
+}
+
+// Draw moves up to count cards from the top of a player's deck to their hand, returning how many were drawn.
+// When the deck runs out the discard pile is shuffled to make a new deck.
+func (game *GameState) Draw(player *Player, count int) int {
+	drawn := 0
+	for ; drawn < count; drawn++ {
+		if player.Deck.Len() == 0 {
+			game.reshuffle(player)
+		}
+		card := player.Deck.Pop()
+		if card == nil {
+			break
+		}
+		player.Hand.Push(card)
+	}
+	return drawn
+}
+
+// reshuffle shuffles a player's discard pile into a new deck
+func (game *GameState) reshuffle(player *Player) {
+	player.Deck.Push(player.Discard.Cards...)
+	player.Discard.Cards = player.Discard.Cards[:0]
+	player.Deck.Shuffle(game.Rand)
+}
+
+// Gain moves the top card of a supply pile to a player's discard pile, nothing is gained from an empty pile
+func (game *GameState) Gain(player *Player, pile *Pile) *Card {
+	card := pile.Pop()
+	if card != nil {
+		player.Discard.Push(card)
+	}
+	return card
+}
+
+// resolve applies queued effects in order until the queue is empty or a decision is needed
+// that no decider can answer
+func (game *GameState) resolve() {
+	for game.decision != nil || len(game.queue) > 0 {
+		if game.decision != nil {
+			if !game.autoAnswer() {
+				return
+			}
+			continue
+		}
+		pending := game.queue[0]
+		game.queue = game.queue[1:]
+		game.apply(pending)
+	}
+}
+
+// counterAmount is how much a '+N <noun>' effect adds, N for each card of its Per type the player has in play
+func (game *GameState) counterAmount(player *Player, effect Effect) int {
+	if effect.Per == "" {
+		return effect.Amount
+	}
+	return effect.Amount * game.CountInPlay(player, effect.Per)
+}
+
+// apply resolves a single effect, effects that need a choice leave a pending decision
+func (game *GameState) apply(pending pendingEffect) {
+	player := game.Players[pending.player]
+	effect := pending.effect
+
+	switch effect.Verb {
+	case DRAW_CARDS:
+		game.Draw(player, game.counterAmount(player, effect))
+	case ADD_ACTIONS:
+		game.StateManager.AddActions(game.counterAmount(player, effect))
+	case ADD_BUYS:
+		game.StateManager.AddBuys(game.counterAmount(player, effect))
+	case ADD_COINS:
+		game.StateManager.AddCoins(game.counterAmount(player, effect))
+	case GAIN:
+		// named cards like the infections of attacks are handed out in turn order until their pile runs out
+		if effect.Card != "" {
+			if pile := game.SupplyPile(effect.Card); pile != nil {
+				game.Gain(player, pile)
+			}
+			return
+		}
+		options := make([]Option, 0)
+		for _, pile := range game.Supply {
+			if pile.Len() > 0 && game.Definition(pile.Top()).Cost <= effect.Amount {
+				options = append(options, Option{Name: pile.Name, Pile: pile})
+			}
+		}
+		game.decide(pending, CHOOSE_PILE, fmt.Sprintf("Gain a card costing up to %d", effect.Amount), options, 1, 1)
+	case TRASH_CARDS:
+		game.decide(pending, CHOOSE_CARDS, fmt.Sprintf("Trash up to %d cards", effect.Amount), handOptions(player), 0, effect.Amount)
+	case DISCARD_TO:
+		excess := player.Hand.Len() - effect.Amount
+		game.decide(pending, CHOOSE_CARDS, fmt.Sprintf("Discard down to %d cards", effect.Amount), handOptions(player), excess, excess)
+	case OTHERS:
+		attack := pending.source != nil && game.Definition(pending.source).Is(ATTACK)
+		others := make([]pendingEffect, 0, len(game.Players)-1)
+		for offset := 1; offset < len(game.Players); offset++ {
+			other := (pending.player + offset) % len(game.Players)
+			if attack && game.unaffected[other] {
+				continue
+			}
+			others = append(others, pendingEffect{player: other, effect: *effect.Inner, source: pending.source})
+		}
+		game.queue = append(others, game.queue...)
+	case reveal:
+		if game.unaffected[pending.player] || !player.Hand.Contains(pending.reaction) {
+			return
+		}
+		options := []Option{{Name: "yes", Card: pending.reaction}, {Name: "no"}}
+		prompt := fmt.Sprintf("Reveal %s to react to %s?", pending.reaction.Name, pending.source.Name)
+		game.decide(pending, YES_NO, prompt, options, 1, 1)
+	}
+}
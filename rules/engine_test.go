@@ -0,0 +1,365 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/quartermeat/card_game/gamestates"
+)
+
+// newTestGame sets up two players on the first turn with a supply of every test card
+func newTestGame(t *testing.T) *GameState {
+	t.Helper()
+	game := NewGameState(testDefinitions(t), gamestates.NewStateManager(), 1)
+	for _, name := range game.Definitions.Names() {
+		game.AddSupplyPile(name, 10)
+	}
+	game.AddPlayer("player", true)
+	game.AddPlayer("ai", false)
+	game.Start()
+	return game
+}
+
+// giveCard puts a new card straight into a player's hand
+func giveCard(game *GameState, player *Player, name string) *Card {
+	card := game.NewCard(name)
+	player.Hand.Push(card)
+	return card
+}
+
+func TestPlayActionDrawsCards(t *testing.T) {
+	game := newTestGame(t)
+	player := game.Players[0]
+	reload := giveCard(game, player, "reload")
+
+	if err := game.PlayAction(player, reload); err != nil {
+		t.Fatal(err)
+	}
+	if player.Hand.Len() != HAND_SIZE+3 || player.InPlay.Top() != reload {
+		t.Errorf("expected %d cards in hand and reload in play, got %d in hand", HAND_SIZE+3, player.Hand.Len())
+	}
+	if game.StateManager.GetActions() != 0 {
+		t.Errorf("expected the action to be used")
+	}
+	if err := game.PlayAction(player, giveCard(game, player, "reload")); err != ErrNoActions {
+		t.Errorf("expected ErrNoActions, got %v", err)
+	}
+}
+
+func TestPlayActionRejectsTreasure(t *testing.T) {
+	game := newTestGame(t)
+	player := game.Players[0]
+
+	if err := game.PlayAction(player, giveCard(game, player, "bullet")); err != ErrNotAnAction {
+		t.Errorf("expected ErrNotAnAction, got %v", err)
+	}
+	if err := game.PlayAction(player, game.NewCard("reload")); err != ErrNotInHand {
+		t.Errorf("expected ErrNotInHand, got %v", err)
+	}
+}
+
+func TestDecisionsPauseResolution(t *testing.T) {
+	game := newTestGame(t)
+	player := game.Players[0]
+	scavenger := giveCard(game, player, "scavenger")
+	bullet := giveCard(game, player, "bullet")
+
+	if err := game.PlayAction(player, scavenger); err != nil {
+		t.Fatal(err)
+	}
+	decision := game.PendingDecision()
+	if decision == nil || decision.Max != 1 || decision.Min != 0 || len(decision.Options) != HAND_SIZE+1 {
+		t.Fatalf("expected a decision to trash up to 1 card, got %+v", decision)
+	}
+	if err := game.PlayAction(player, bullet); err != ErrDecisionPending {
+		t.Errorf("expected ErrDecisionPending, got %v", err)
+	}
+	if err := game.Answer([]int{0, 0}); err == nil {
+		t.Error("expected picking too many options to fail")
+	}
+	if err := game.Answer([]int{HAND_SIZE}); err != nil {
+		t.Fatal(err)
+	}
+	if game.Trash.Top() != bullet {
+		t.Fatal("expected the bullet to be trashed")
+	}
+
+	decision = game.PendingDecision()
+	if decision == nil || decision.Min != 1 {
+		t.Fatalf("expected a decision to gain a card, got %+v", decision)
+	}
+	for _, option := range decision.Options {
+		if game.Definition(option.Pile.Top()).Cost > 4 {
+			t.Errorf("%s costs more than 4", option.Name)
+		}
+	}
+	if err := game.Answer([]int{0}); err != nil {
+		t.Fatal(err)
+	}
+	if player.Discard.Len() != 1 || game.PendingDecision() != nil {
+		t.Errorf("expected one gained card and no decision left")
+	}
+	if err := game.Answer([]int{0}); err != ErrNoDecision {
+		t.Errorf("expected ErrNoDecision, got %v", err)
+	}
+}
+
+func TestOthersResolveForEachOtherPlayer(t *testing.T) {
+	game := newTestGame(t)
+	player, ai := game.Players[0], game.Players[1]
+
+	if err := game.PlayAction(player, giveCard(game, player, "molotov_cocktail")); err != nil {
+		t.Fatal(err)
+	}
+	if game.StateManager.GetCoins() != 2 {
+		t.Errorf("expected 2 coins, got %d", game.StateManager.GetCoins())
+	}
+	decision := game.PendingDecision()
+	if decision == nil || decision.Player != 1 || decision.Min != 2 || decision.Max != 2 {
+		t.Fatalf("expected the ai to discard 2 cards, got %+v", decision)
+	}
+	if err := game.Answer([]int{0, 1}); err != nil {
+		t.Fatal(err)
+	}
+	if ai.Hand.Len() != 3 || ai.Discard.Len() != 2 {
+		t.Errorf("expected 3 cards in hand and 2 discarded, got %d and %d", ai.Hand.Len(), ai.Discard.Len())
+	}
+
+	game.StateManager.AddActions(1)
+	if err := game.PlayAction(player, giveCard(game, player, "zombie_swarm")); err != nil {
+		t.Fatal(err)
+	}
+	if ai.Discard.Top().Name != "infection" {
+		t.Error("expected the ai to gain an infection")
+	}
+}
+
+func TestDrawReshufflesDiscard(t *testing.T) {
+	game := newTestGame(t)
+	player := game.Players[0]
+
+	if drawn := game.Draw(player, 5); drawn != 5 || player.Deck.Len() != 0 {
+		t.Fatalf("expected to draw the rest of the deck, drew %d", drawn)
+	}
+	for player.Hand.Len() > 3 {
+		MoveCard(player.Hand.Top(), player.Hand, player.Discard)
+	}
+
+	if drawn := game.Draw(player, 5); drawn != 5 {
+		t.Errorf("expected 5 cards drawn after reshuffling, got %d", drawn)
+	}
+	if player.Deck.Len() != 2 || player.Discard.Len() != 0 || player.Hand.Len() != 8 {
+		t.Errorf("expected deck 2, discard 0, hand 8, got %d, %d, %d", player.Deck.Len(), player.Discard.Len(), player.Hand.Len())
+	}
+	if drawn := game.Draw(player, 5); drawn != 2 {
+		t.Errorf("expected only the 2 cards left to be drawn, got %d", drawn)
+	}
+}
+
+func TestOpeningHandAndCleanup(t *testing.T) {
+	game := newTestGame(t)
+	player, ai := game.Players[0], game.Players[1]
+
+	for _, seat := range game.Players {
+		if seat.Hand.Len() != HAND_SIZE || seat.Deck.Len() != starting_bullets+starting_zombies-HAND_SIZE {
+			t.Fatalf("expected an opening hand of %d drawn from the deck, got %d", HAND_SIZE, seat.Hand.Len())
+		}
+	}
+	opening := append([]*Card{}, player.Hand.Cards...)
+	reload := giveCard(game, player, "reload")
+	if err := game.PlayAction(player, reload); err != nil {
+		t.Fatal(err)
+	}
+
+	game.EndPhase()
+	if phase, _ := game.EndPhase(); phase != gamestates.CleanupPhase {
+		t.Fatalf("expected cleanup, got %s", phase)
+	}
+	if player.InPlay.Len() != 0 || player.Hand.Len() != HAND_SIZE {
+		t.Errorf("expected cleanup to clear play and draw a new hand, got %d in play and %d in hand", player.InPlay.Len(), player.Hand.Len())
+	}
+	for _, card := range opening {
+		found := false
+		for _, owned := range player.Cards() {
+			found = found || owned == card
+		}
+		if !found {
+			t.Errorf("card %d left the player's zones", card.ID)
+		}
+	}
+
+	game.EndPhase()
+	if game.CurrentPlayer() != ai || game.StateManager.GetCurrentState() != gamestates.AiTurn {
+		t.Errorf("expected the ai's turn")
+	}
+	if err := game.PlayAction(player, giveCard(game, player, "reload")); err != ErrNotYourTurn {
+		t.Errorf("expected ErrNotYourTurn, got %v", err)
+	}
+}
+
+func TestTreasuresBuyFromSupply(t *testing.T) {
+	game := newTestGame(t)
+	player := game.Players[0]
+	player.Hand.Cards = player.Hand.Cards[:0]
+	giveCard(game, player, "bullet")
+	giveCard(game, player, "slug")
+	giveCard(game, player, "zombies")
+	slugs := game.SupplyPile("slug")
+
+	if err := game.PlayAllTreasures(player); err != nil {
+		t.Fatal(err)
+	}
+	if game.StateManager.GetPhase() != gamestates.BuyPhase || game.StateManager.GetCoins() != 3 {
+		t.Fatalf("expected 3 coins in the buy phase, got %d in %s", game.StateManager.GetCoins(), game.StateManager.GetPhase())
+	}
+	if player.Hand.Len() != 1 || player.InPlay.Len() != 2 {
+		t.Errorf("expected the treasures in play and the zombies in hand")
+	}
+	if err := game.PlayTreasure(player, player.Hand.Top()); err != ErrNotATreasure {
+		t.Errorf("expected ErrNotATreasure, got %v", err)
+	}
+	if err := game.Buy(player, game.SupplyPile("reload")); err != ErrCantAfford {
+		t.Errorf("expected ErrCantAfford, got %v", err)
+	}
+
+	bought := slugs.Top()
+	if err := game.Buy(player, slugs); err != nil {
+		t.Fatal(err)
+	}
+	if player.Discard.Top() != bought || slugs.Len() != 9 {
+		t.Errorf("expected the bought slug in the discard pile")
+	}
+	if err := game.Buy(player, game.SupplyPile("bullet")); err != ErrCantAfford {
+		t.Errorf("expected no buys left, got %v", err)
+	}
+}
+
+func TestBuyFromEmptyPile(t *testing.T) {
+	game := newTestGame(t)
+	player := game.Players[0]
+	empty := game.SupplyPile("zombies")
+	empty.Cards = empty.Cards[:0]
+
+	if err := game.Buy(player, empty); err != ErrEmptyPile {
+		t.Errorf("expected ErrEmptyPile, got %v", err)
+	}
+	if err := game.Buy(game.Players[1], game.SupplyPile("bullet")); err != ErrNotYourTurn {
+		t.Errorf("expected ErrNotYourTurn, got %v", err)
+	}
+}
+
+func TestRejectedMovesKeepTheActionPhase(t *testing.T) {
+	game := newTestGame(t)
+	player := game.Players[0]
+	player.Hand.Cards = player.Hand.Cards[:0]
+
+	if err := game.Buy(player, game.SupplyPile("reload")); err != ErrCantAfford {
+		t.Errorf("expected ErrCantAfford, got %v", err)
+	}
+	if err := game.PlayTreasure(player, game.NewCard("bullet")); err != ErrNotInHand {
+		t.Errorf("expected ErrNotInHand, got %v", err)
+	}
+	if game.StateManager.GetPhase() != gamestates.ActionPhase || game.StateManager.GetActions() != 1 {
+		t.Errorf("expected the action phase with 1 action, got %d actions in %s", game.StateManager.GetActions(), game.StateManager.GetPhase())
+	}
+}
+
+func TestCountInPlay(t *testing.T) {
+	game := newTestGame(t)
+	player := game.Players[0]
+
+	game.PlayAction(player, giveCard(game, player, "reload"))
+	game.PlayTreasure(player, giveCard(game, player, "bullet"))
+	game.PlayTreasure(player, giveCard(game, player, "slug"))
+	if actions := game.CountInPlay(player, ACTION); actions != 1 {
+		t.Errorf("expected 1 action in play, got %d", actions)
+	}
+	if treasures := game.CountInPlay(player, TREASURE); treasures != 2 {
+		t.Errorf("expected 2 treasures in play, got %d", treasures)
+	}
+	if others := game.CountInPlay(game.Players[1], TREASURE); others != 0 {
+		t.Errorf("expected nothing in play for the other player, got %d", others)
+	}
+}
+
+func TestCoinsPerActionInPlay(t *testing.T) {
+	game := newTestGame(t)
+	player := game.Players[0]
+	effects, err := ParseEffects("+1 action; +1 coin per action")
+	if err != nil {
+		t.Fatal(err)
+	}
+	game.Definitions["rally"] = &CardDefinition{Name: "rally", Cost: 3, Types: []CardType{ACTION}, Effects: effects}
+
+	game.PlayAction(player, giveCard(game, player, "rally"))
+	if coins := game.StateManager.GetCoins(); coins != 1 {
+		t.Errorf("expected a coin for rally itself, got %d", coins)
+	}
+	game.PlayAction(player, giveCard(game, player, "rally"))
+	if coins := game.StateManager.GetCoins(); coins != 3 {
+		t.Errorf("expected 2 more coins for both rallies in play, got %d", coins)
+	}
+}
+
+func TestInfectionsRunOutInTurnOrder(t *testing.T) {
+	game := newTestGame(t)
+	game.AddPlayer("third", false)
+	game.AddPlayer("fourth", false)
+	player := game.Players[0]
+	infections := game.SupplyPile(INFECTION)
+	infections.Cards = infections.Cards[:2]
+
+	if err := game.PlayAction(player, giveCard(game, player, "zombie_swarm")); err != nil {
+		t.Fatal(err)
+	}
+	infected := make([]int, 0)
+	for seat, other := range game.Players {
+		if top := other.Discard.Top(); top != nil && top.Name == INFECTION {
+			infected = append(infected, seat)
+		}
+	}
+	if len(infected) != 2 || infected[0] != 1 || infected[1] != 2 {
+		t.Errorf("expected the next two players to get the last infections, got seats %v", infected)
+	}
+	if infections.Len() != 0 || game.PendingDecision() != nil {
+		t.Error("expected the attack to finish with the pile empty")
+	}
+	if score := game.Score(game.Players[1]); score != starting_zombies-1 {
+		t.Errorf("expected an infection to cost a victory point, got %d", score)
+	}
+}
+
+func TestInfectionPileSize(t *testing.T) {
+	for players, size := range map[int]int{1: 0, 2: 10, 3: 20, 4: 30} {
+		if got := InfectionPileSize(players); got != size {
+			t.Errorf("%d players: expected %d infections, got %d", players, size, got)
+		}
+	}
+}
+
+func TestTurnsRotateAcrossSeats(t *testing.T) {
+	game := NewGameState(testDefinitions(t), gamestates.NewStateManager(), 1)
+	for _, name := range game.Definitions.Names() {
+		game.AddSupplyPile(name, 10)
+	}
+	humans := []bool{true, false, true, false}
+	for seat, human := range humans {
+		game.AddPlayer(fmt.Sprintf("seat%d", seat), human)
+	}
+	game.Start()
+
+	for turn := 0; turn < 2*len(humans); turn++ {
+		seat := turn % len(humans)
+		state := gamestates.AiTurn
+		if humans[seat] {
+			state = gamestates.PlayerTurn
+		}
+		if game.Current != seat || game.StateManager.GetSeat() != seat || game.StateManager.GetCurrentState() != state {
+			t.Fatalf("turn %d: expected seat %d in state %d, got seat %d in state %d", turn, seat, state, game.Current, game.StateManager.GetCurrentState())
+		}
+		if game.CurrentPlayer().Turns != turn/len(humans)+1 {
+			t.Fatalf("turn %d: expected seat %d to be on turn %d", turn, seat, turn/len(humans)+1)
+		}
+		finishTurn(game)
+	}
+}
@@ -0,0 +1,159 @@
+package rules
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/quartermeat/card_game/gamestates"
+)
+
+const (
+	// card names the rules need to know about
+	BULLET            = "bullet"
+	ZOMBIES           = "zombies"
+	EVEN_MORE_ZOMBIES = "even_more_zombies"
+	INFECTION         = "infection"
+
+	// zone names
+	DECK    = "deck"
+	HAND    = "hand"
+	DISCARD = "discard"
+	IN_PLAY = "in_play"
+	TRASH   = "trash"
+
+	starting_bullets = 7
+	starting_zombies = 3
+	// INFECTIONS_PER_OPPONENT is how many infections go in the supply for each of a player's opponents
+	INFECTIONS_PER_OPPONENT = 10
+	// HAND_SIZE is the number of cards drawn at the start of the game and in every cleanup
+	HAND_SIZE = 5
+)
+
+// InfectionPileSize is the number of infections in the supply for a game of players
+func InfectionPileSize(players int) int {
+	if players < 2 {
+		return 0
+	}
+	return INFECTIONS_PER_OPPONENT * (players - 1)
+}
+
+// Player holds the zones owned by a single player
+type Player struct {
+	Name    string
+	Deck    *Pile
+	Hand    *Pile
+	Discard *Pile
+	InPlay  *Pile
+	// Human is true for a seat played by a person, false for the AI
+	Human bool
+	// Turns is the number of turns the player has started
+	Turns int
+}
+
+// Cards returns every card the player owns across all of their zones
+func (player *Player) Cards() []*Card {
+	cards := make([]*Card, 0)
+	for _, pile := range []*Pile{player.Deck, player.Hand, player.Discard, player.InPlay} {
+		cards = append(cards, pile.Cards...)
+	}
+	return cards
+}
+
+// GameState is the complete model of a game: the supply, every player's zones and the trash,
+// along with the turn tracked by the state manager and the effects still to resolve
+type GameState struct {
+	Definitions  CardDefinitions
+	StateManager *gamestates.StateManager
+	Supply       []*Pile
+	Players      []*Player
+	Trash        *Pile
+	Current      int // index of the player taking their turn
+	Seed         int64
+	Rand         *rand.Rand // every shuffle and random choice comes from here so a seed replays the game
+	Kingdom      []string   // the kingdom cards chosen for the supply
+	nextID       int
+	queue        []pendingEffect
+	decision     *Decision
+	deciders     map[int]Decider
+	unaffected   map[int]bool // players who blocked the attack being resolved
+}
+
+// NewGameState creates an empty game using the card definitions and the turns of the state manager,
+// supply piles and players are added during setup. Games created with the same seed play out the same way
+func NewGameState(definitions CardDefinitions, stateManager *gamestates.StateManager, seed int64) *GameState {
+	return &GameState{
+		Definitions:  definitions,
+		StateManager: stateManager,
+		Seed:         seed,
+		Rand:         rand.New(rand.NewSource(seed)),
+		Supply:       make([]*Pile, 0),
+		Players:      make([]*Player, 0),
+		Trash:        NewPile(TRASH),
+	}
+}
+
+// Definition returns the definition of a card, nil if the card isn't defined
+func (game *GameState) Definition(card *Card) *CardDefinition {
+	return game.Definitions[card.Name]
+}
+
+// CountInPlay counts the cards the player has in play this turn that have the card type
+func (game *GameState) CountInPlay(player *Player, cardType CardType) int {
+	count := 0
+	for _, card := range player.InPlay.Cards {
+		if def := game.Definition(card); def != nil && def.Is(cardType) {
+			count++
+		}
+	}
+	return count
+}
+
+// NewCard creates a card with a unique ID within this game
+func (game *GameState) NewCard(name string) *Card {
+	card := &Card{
+		ID:   game.nextID,
+		Name: name,
+	}
+	game.nextID++
+	return card
+}
+
+// AddSupplyPile adds a pile of count new cards to the supply
+func (game *GameState) AddSupplyPile(name string, count int) *Pile {
+	pile := NewPile(name)
+	for i := 0; i < count; i++ {
+		pile.Push(game.NewCard(name))
+	}
+	game.Supply = append(game.Supply, pile)
+	return pile
+}
+
+// SupplyPile returns the supply pile of the named card, nil if it isn't in the supply
+func (game *GameState) SupplyPile(name string) *Pile {
+	for _, pile := range game.Supply {
+		if pile.Name == name {
+			return pile
+		}
+	}
+	return nil
+}
+
+// AddPlayer seats a player, a person or the AI, with a shuffled starting deck of bullets and zombies
+func (game *GameState) AddPlayer(name string, human bool) *Player {
+	player := &Player{
+		Name:    name,
+		Human:   human,
+		Deck:    NewPile(fmt.Sprintf("%s_%s", name, DECK)),
+		Hand:    NewPile(fmt.Sprintf("%s_%s", name, HAND)),
+		Discard: NewPile(fmt.Sprintf("%s_%s", name, DISCARD)),
+		InPlay:  NewPile(fmt.Sprintf("%s_%s", name, IN_PLAY)),
+	}
+	for _, starting := range startingDeck {
+		for i := 0; i < starting.Count; i++ {
+			player.Deck.Push(game.NewCard(starting.Name))
+		}
+	}
+	player.Deck.Shuffle(game.Rand)
+	game.Players = append(game.Players, player)
+	return player
+}
@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/quartermeat/card_game/gamestates"
+)
+
+func TestAddPlayerStartingDeck(t *testing.T) {
+	game := NewGameState(testDefinitions(t), gamestates.NewStateManager(), 1)
+	player := game.AddPlayer("player", true)
+
+	if player.Deck.Len() != starting_bullets+starting_zombies {
+		t.Fatalf("expected %d cards in deck, got %d", starting_bullets+starting_zombies, player.Deck.Len())
+	}
+	counts := make(map[string]int)
+	for _, card := range player.Cards() {
+		counts[card.Name]++
+	}
+	if counts[BULLET] != starting_bullets || counts[ZOMBIES] != starting_zombies {
+		t.Errorf("unexpected starting deck: %v", counts)
+	}
+}
+
+func TestMoveCardKeepsInstance(t *testing.T) {
+	game := NewGameState(testDefinitions(t), gamestates.NewStateManager(), 1)
+	supply := game.AddSupplyPile(BULLET, 3)
+	player := game.AddPlayer("player", true)
+
+	card := supply.Top()
+	if !MoveCard(card, supply, player.Discard) {
+		t.Fatal("expected card to move")
+	}
+	if supply.Len() != 2 || player.Discard.Top() != card {
+		t.Errorf("card did not move from supply to discard")
+	}
+	if MoveCard(card, supply, player.Hand) {
+		t.Error("moved a card from a pile that does not hold it")
+	}
+}
+
+func TestCardIDsAreUnique(t *testing.T) {
+	game := NewGameState(testDefinitions(t), gamestates.NewStateManager(), 1)
+	game.AddSupplyPile(BULLET, 10)
+	game.AddPlayer("player", true)
+	game.AddPlayer("ai", false)
+
+	seen := make(map[int]bool)
+	for _, pile := range game.Supply {
+		for _, card := range pile.Cards {
+			seen[card.ID] = true
+		}
+	}
+	for _, player := range game.Players {
+		for _, card := range player.Cards() {
+			if seen[card.ID] {
+				t.Fatalf("duplicate card id %d", card.ID)
+			}
+			seen[card.ID] = true
+		}
+	}
+}
+
+func TestSameSeedSameGame(t *testing.T) {
+	order := func(seed int64) []string {
+		game := NewGameState(testDefinitions(t), gamestates.NewStateManager(), seed)
+		player := game.AddPlayer("player", true)
+		game.Draw(player, 20)
+		names := make([]string, 0)
+		for _, card := range player.Hand.Cards {
+			names = append(names, card.Name)
+		}
+		return names
+	}
+	first, replay := order(42), order(42)
+	for i := range first {
+		if first[i] != replay[i] {
+			t.Fatalf("expected seed 42 to replay the same draws, got %v and %v", first, replay)
+		}
+	}
+}
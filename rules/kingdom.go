@@ -0,0 +1,240 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// KINGDOM_SIZE is the number of kingdom piles in the supply
+	KINGDOM_SIZE = 10
+	// random draws that break a rule are redrawn this many times before giving up
+	kingdom_attempts = 1000
+)
+
+// KingdomPresets are hand picked kingdoms that can be chosen by name
+var KingdomPresets = map[string][]string{
+	"first_night": {"ammo_box", "barricade", "cunning", "hide", "higher_ground", "reload", "scavenger", "stick_together", "survivors", "weapons_cache"},
+	"big_guns":    {"1_in_the_chamber", "courage", "hollow_points", "maverick", "molotov_cocktail", "regroup", "restock", "shotgun", "sidekick", "tactics"},
+	"outbreak":    {"decoy", "ham_radio", "hide", "molotov_cocktail", "quick_escape", "recon", "sacrifice", "shotgun", "survivors", "zombie_swarm"},
+}
+
+// KingdomRule is a constraint on a randomly drawn kingdom
+type KingdomRule struct {
+	Name  string
+	Check func(kingdom []*CardDefinition) bool
+}
+
+// AtLeastActions requires n cards in the kingdom that give +actions
+func AtLeastActions(n int) KingdomRule {
+	return KingdomRule{
+		Name: fmt.Sprintf("at least %d +actions cards", n),
+		Check: func(kingdom []*CardDefinition) bool {
+			return countKingdom(kingdom, givesActions) >= n
+		},
+	}
+}
+
+// AtMostAttacks limits the kingdom to n attack cards
+func AtMostAttacks(n int) KingdomRule {
+	return KingdomRule{
+		Name: fmt.Sprintf("at most %d attacks", n),
+		Check: func(kingdom []*CardDefinition) bool {
+			return countKingdom(kingdom, func(def *CardDefinition) bool { return def.Is(ATTACK) }) <= n
+		},
+	}
+}
+
+func countKingdom(kingdom []*CardDefinition, match func(def *CardDefinition) bool) int {
+	count := 0
+	for _, def := range kingdom {
+		if match(def) {
+			count++
+		}
+	}
+	return count
+}
+
+func givesActions(def *CardDefinition) bool {
+	for _, effect := range def.Effects {
+		if effect.Verb == ADD_ACTIONS {
+			return true
+		}
+	}
+	return false
+}
+
+// KingdomConfig says how to choose the kingdom: a named preset, an explicit list of cards,
+// or when neither is given a random draw that keeps to the required and banned cards and the rules
+type KingdomConfig struct {
+	Preset   string
+	Cards    []string
+	Required []string
+	Banned   []string
+	Rules    []KingdomRule
+}
+
+// DefaultKingdomConfig draws a random kingdom with at least one +actions card
+func DefaultKingdomConfig() KingdomConfig {
+	return KingdomConfig{Rules: []KingdomRule{AtLeastActions(1)}}
+}
+
+// ParseKingdomConfig reads a kingdom setting: a preset name, a comma separated list of kingdom
+// cards, or nothing for the default random kingdom
+func ParseKingdomConfig(kingdom string) KingdomConfig {
+	names := make([]string, 0)
+	for _, name := range strings.Split(kingdom, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	switch len(names) {
+	case 0:
+		return DefaultKingdomConfig()
+	case 1:
+		return KingdomConfig{Preset: names[0]}
+	}
+	return KingdomConfig{Cards: names}
+}
+
+// IsKingdomCard returns true for cards that can be chosen for the kingdom,
+// treasures, victory cards and curses are always in the supply
+func IsKingdomCard(def *CardDefinition) bool {
+	return !def.Is(TREASURE) && !def.Is(VICTORY) && !def.Is(CURSE)
+}
+
+// ChooseKingdom picks the kingdom cards for the game, random draws come from the game's RNG
+// so the same seed and config always give the same kingdom
+func (game *GameState) ChooseKingdom(config KingdomConfig) ([]string, error) {
+	var (
+		kingdom []string
+		err     error
+	)
+	switch {
+	case config.Preset != "":
+		preset, ok := KingdomPresets[config.Preset]
+		if !ok {
+			return nil, errors.Errorf("unknown kingdom preset %s", config.Preset)
+		}
+		kingdom, err = game.checkKingdom(preset)
+	case len(config.Cards) > 0:
+		kingdom, err = game.checkKingdom(config.Cards)
+	default:
+		kingdom, err = game.drawKingdom(config)
+	}
+	if err != nil {
+		return nil, err
+	}
+	game.Kingdom = kingdom
+	return kingdom, nil
+}
+
+// checkKingdom makes sure a chosen kingdom is KINGDOM_SIZE different kingdom cards
+func (game *GameState) checkKingdom(names []string) ([]string, error) {
+	if len(names) != KINGDOM_SIZE {
+		return nil, errors.Errorf("a kingdom needs %d cards, got %d", KINGDOM_SIZE, len(names))
+	}
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if err := game.checkKingdomCard(name); err != nil {
+			return nil, err
+		}
+		if seen[name] {
+			return nil, errors.Errorf("%s is in the kingdom twice", name)
+		}
+		seen[name] = true
+	}
+	return append([]string(nil), names...), nil
+}
+
+func (game *GameState) checkKingdomCard(name string) error {
+	def, ok := game.Definitions[name]
+	if !ok {
+		return errors.Errorf("%s has no definition", name)
+	}
+	if !IsKingdomCard(def) {
+		return errors.Errorf("%s is not a kingdom card", name)
+	}
+	return nil
+}
+
+// drawKingdom fills the kingdom with the required cards and random picks from the rest,
+// drawing again until every rule is kept
+func (game *GameState) drawKingdom(config KingdomConfig) ([]string, error) {
+	banned := make(map[string]bool)
+	for _, name := range config.Banned {
+		banned[name] = true
+	}
+	required := make(map[string]bool)
+	for _, name := range config.Required {
+		if err := game.checkKingdomCard(name); err != nil {
+			return nil, err
+		}
+		if banned[name] {
+			return nil, errors.Errorf("%s is both required and banned", name)
+		}
+		required[name] = true
+	}
+	if len(required) > KINGDOM_SIZE {
+		return nil, errors.Errorf("%d cards are required but a kingdom only has %d", len(required), KINGDOM_SIZE)
+	}
+
+	// Names is sorted, so the candidates are in the same order every time
+	candidates := make([]string, 0)
+	for _, name := range game.Definitions.Names() {
+		if IsKingdomCard(game.Definitions[name]) && !banned[name] && !required[name] {
+			candidates = append(candidates, name)
+		}
+	}
+	picks := KINGDOM_SIZE - len(required)
+	if len(candidates) < picks {
+		return nil, errors.Errorf("only %d kingdom cards left to fill %d places", len(candidates), picks)
+	}
+
+	for attempt := 0; attempt < kingdom_attempts; attempt++ {
+		game.Rand.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+		kingdom := make([]string, 0, KINGDOM_SIZE)
+		for _, name := range config.Required {
+			if !contains(kingdom, name) {
+				kingdom = append(kingdom, name)
+			}
+		}
+		kingdom = append(kingdom, candidates[:picks]...)
+		sort.Strings(kingdom)
+		if game.keepsRules(kingdom, config.Rules) {
+			return kingdom, nil
+		}
+	}
+	names := make([]string, 0, len(config.Rules))
+	for _, rule := range config.Rules {
+		names = append(names, rule.Name)
+	}
+	return nil, errors.Errorf("no kingdom found that keeps: %s", strings.Join(names, ", "))
+}
+
+func (game *GameState) keepsRules(kingdom []string, rules []KingdomRule) bool {
+	defs := make([]*CardDefinition, 0, len(kingdom))
+	for _, name := range kingdom {
+		defs = append(defs, game.Definitions[name])
+	}
+	for _, rule := range rules {
+		if !rule.Check(defs) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(names []string, name string) bool {
+	for _, candidate := range names {
+		if candidate == name {
+			return true
+		}
+	}
+	return false
+}
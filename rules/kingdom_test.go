@@ -0,0 +1,100 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/quartermeat/card_game/gamestates"
+)
+
+func newShippedGame(t *testing.T, seed int64) *GameState {
+	defs, err := LoadCardDefinitions("../assets/cards/cardDefinitions.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewGameState(defs, gamestates.NewStateManager(), seed)
+}
+
+func TestPresetKingdomsAreValid(t *testing.T) {
+	for name := range KingdomPresets {
+		game := newShippedGame(t, 1)
+		if _, err := game.ChooseKingdom(KingdomConfig{Preset: name}); err != nil {
+			t.Errorf("preset %s: %v", name, err)
+		}
+	}
+	if _, err := newShippedGame(t, 1).ChooseKingdom(KingdomConfig{Preset: "missing"}); err == nil {
+		t.Error("expected an unknown preset to fail")
+	}
+}
+
+func TestExplicitKingdomIsChecked(t *testing.T) {
+	game := newShippedGame(t, 1)
+	cards := append([]string(nil), KingdomPresets["first_night"]...)
+	if kingdom, err := game.ChooseKingdom(KingdomConfig{Cards: cards}); err != nil || game.Kingdom[0] != kingdom[0] {
+		t.Fatalf("expected the explicit kingdom, got %v %v", kingdom, err)
+	}
+	cards[0] = "bullet"
+	if _, err := game.ChooseKingdom(KingdomConfig{Cards: cards}); err == nil {
+		t.Error("expected a treasure in the kingdom to fail")
+	}
+	cards[0] = cards[1]
+	if _, err := game.ChooseKingdom(KingdomConfig{Cards: cards}); err == nil {
+		t.Error("expected a repeated card to fail")
+	}
+	if _, err := game.ChooseKingdom(KingdomConfig{Cards: cards[:9]}); err == nil {
+		t.Error("expected a short kingdom to fail")
+	}
+}
+
+func TestRandomKingdomKeepsRules(t *testing.T) {
+	config := KingdomConfig{
+		Required: []string{"shotgun"},
+		Banned:   []string{"hide", "survivors"},
+		Rules:    []KingdomRule{AtLeastActions(1), AtMostAttacks(1)},
+	}
+	for seed := int64(0); seed < 50; seed++ {
+		game := newShippedGame(t, seed)
+		kingdom, err := game.ChooseKingdom(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(kingdom) != KINGDOM_SIZE || !contains(kingdom, "shotgun") || contains(kingdom, "hide") || contains(kingdom, "survivors") {
+			t.Fatalf("seed %d broke the required or banned cards: %v", seed, kingdom)
+		}
+		if !game.keepsRules(kingdom, config.Rules) {
+			t.Fatalf("seed %d broke the rules: %v", seed, kingdom)
+		}
+	}
+}
+
+func TestRandomKingdomRepeatsFromSeed(t *testing.T) {
+	first, _ := newShippedGame(t, 7).ChooseKingdom(DefaultKingdomConfig())
+	// a second game in the same process draws from the full set again
+	replay, _ := newShippedGame(t, 7).ChooseKingdom(DefaultKingdomConfig())
+	for i := range first {
+		if first[i] != replay[i] {
+			t.Fatalf("expected the same kingdom from the same seed, got %v and %v", first, replay)
+		}
+	}
+}
+
+func TestImpossibleKingdomRules(t *testing.T) {
+	game := newShippedGame(t, 1)
+	if _, err := game.ChooseKingdom(KingdomConfig{Rules: []KingdomRule{AtLeastActions(KINGDOM_SIZE + 1)}}); err == nil {
+		t.Error("expected rules no kingdom can keep to fail")
+	}
+	if _, err := game.ChooseKingdom(KingdomConfig{Required: []string{"hide"}, Banned: []string{"hide"}}); err == nil {
+		t.Error("expected a card both required and banned to fail")
+	}
+}
+
+func TestParseKingdomConfig(t *testing.T) {
+	if config := ParseKingdomConfig(""); config.Preset != "" || len(config.Cards) != 0 || len(config.Rules) != 1 {
+		t.Errorf("expected the default random kingdom, got %+v", config)
+	}
+	if config := ParseKingdomConfig(" first_night "); config.Preset != "first_night" {
+		t.Errorf("expected a preset, got %+v", config)
+	}
+	if config := ParseKingdomConfig("reload, cunning,"); len(config.Cards) != 2 || config.Cards[1] != "cunning" {
+		t.Errorf("expected a list of cards, got %+v", config)
+	}
+}
@@ -0,0 +1,205 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/quartermeat/card_game/gamestates"
+)
+
+// MoveKind says what a move does
+type MoveKind string
+
+const (
+	// PLAY plays the action card named Card from hand
+	PLAY MoveKind = "play"
+	// BUY plays every treasure in hand and buys from the supply pile named Card
+	BUY MoveKind = "buy"
+	// END_TURN ends what is left of the turn
+	END_TURN MoveKind = "end_turn"
+	// ANSWER answers the pending decision with the options named by Picks
+	ANSWER MoveKind = "answer"
+)
+
+// Move is one whole choice of the seat whose move it is. Moves name cards rather than point
+// at them, so a move found on a clone of the game can be made on the game itself.
+type Move struct {
+	Kind  MoveKind
+	Card  string
+	Picks []string
+}
+
+// String describes the move, like "play reload" or "answer bullet,zombies"
+func (move Move) String() string {
+	switch move.Kind {
+	case PLAY, BUY:
+		return string(move.Kind) + " " + move.Card
+	case ANSWER:
+		return string(move.Kind) + " " + strings.Join(move.Picks, ",")
+	}
+	return string(move.Kind)
+}
+
+// Chooser returns the seat whose move it is: the seat answering the pending decision,
+// otherwise the seat taking its turn, -1 once the game is over
+func (game *GameState) Chooser() int {
+	if game.decision != nil {
+		return game.decision.Player
+	}
+	if !game.StateManager.IsTurn() {
+		return -1
+	}
+	return game.Current
+}
+
+// LegalMoves returns every move the chooser can make, none once the game is over
+func (game *GameState) LegalMoves() []Move {
+	if game.decision != nil {
+		return answerMoves(game.decision)
+	}
+	if !game.StateManager.IsTurn() {
+		return nil
+	}
+	player := game.CurrentPlayer()
+	moves := make([]Move, 0)
+	if game.StateManager.GetPhase() == gamestates.ActionPhase && game.StateManager.GetActions() > 0 {
+		for _, name := range distinctNames(player.Hand.Cards) {
+			if def := game.Definitions[name]; def != nil && def.Is(ACTION) {
+				moves = append(moves, Move{Kind: PLAY, Card: name})
+			}
+		}
+	}
+	if game.StateManager.GetPhase() != gamestates.CleanupPhase && game.StateManager.GetBuys() > 0 {
+		coins := game.StateManager.GetCoins() + game.treasureCoins(player)
+		for _, pile := range game.Supply {
+			if pile.Len() > 0 && game.Definition(pile.Top()).Cost <= coins {
+				moves = append(moves, Move{Kind: BUY, Card: pile.Name})
+			}
+		}
+	}
+	return append(moves, Move{Kind: END_TURN})
+}
+
+// Apply makes a move for the chooser
+func (game *GameState) Apply(move Move) error {
+	if game.decision != nil && move.Kind != ANSWER {
+		return ErrDecisionPending
+	}
+	switch move.Kind {
+	case PLAY:
+		player := game.CurrentPlayer()
+		for _, card := range player.Hand.Cards {
+			if card.Name == move.Card {
+				return game.PlayAction(player, card)
+			}
+		}
+		return ErrNotInHand
+	case BUY:
+		pile := game.SupplyPile(move.Card)
+		if pile == nil {
+			return errors.Errorf("no supply pile called %s", move.Card)
+		}
+		player := game.CurrentPlayer()
+		if err := game.PlayAllTreasures(player); err != nil {
+			return err
+		}
+		return game.Buy(player, pile)
+	case END_TURN:
+		if !game.StateManager.IsTurn() {
+			return ErrWrongPhase
+		}
+		for seat := game.Current; game.Current == seat && game.StateManager.IsTurn(); {
+			if _, err := game.EndPhase(); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ANSWER:
+		if game.decision == nil {
+			return ErrNoDecision
+		}
+		picks, err := game.decision.Indexes(move.Picks)
+		if err != nil {
+			return err
+		}
+		return game.Answer(picks)
+	}
+	return errors.Errorf("unknown move %q", move.Kind)
+}
+
+// Indexes returns the options picked by name, each name taking the next option with that name
+func (decision *Decision) Indexes(names []string) ([]int, error) {
+	picked := make(map[int]bool)
+	picks := make([]int, 0, len(names))
+	for _, name := range names {
+		found := -1
+		for index, option := range decision.Options {
+			if option.Name == name && !picked[index] {
+				found = index
+				break
+			}
+		}
+		if found < 0 {
+			return nil, errors.Errorf("no option %s left to pick", name)
+		}
+		picked[found] = true
+		picks = append(picks, found)
+	}
+	return picks, nil
+}
+
+// treasureCoins is what the treasures in a player's hand would make
+func (game *GameState) treasureCoins(player *Player) int {
+	coins := 0
+	for _, card := range player.Hand.Cards {
+		if def := game.Definition(card); def != nil && def.Is(TREASURE) {
+			coins += def.Coins
+		}
+	}
+	return coins
+}
+
+// answerMoves lists every different answer to a decision, options with the same name
+// being as good as each other
+func answerMoves(decision *Decision) []Move {
+	names := make([]string, 0)
+	counts := make(map[string]int)
+	for _, option := range decision.Options {
+		if counts[option.Name] == 0 {
+			names = append(names, option.Name)
+		}
+		counts[option.Name]++
+	}
+
+	moves := make([]Move, 0)
+	var pick func(next int, picks []string)
+	pick = func(next int, picks []string) {
+		if next == len(names) {
+			if len(picks) >= decision.Min {
+				moves = append(moves, Move{Kind: ANSWER, Picks: append([]string(nil), picks...)})
+			}
+			return
+		}
+		for count := 0; count <= counts[names[next]] && len(picks)+count <= decision.Max; count++ {
+			for added := 0; added < count; added++ {
+				picks = append(picks, names[next])
+			}
+			pick(next+1, picks)
+			picks = picks[:len(picks)-count]
+		}
+	}
+	pick(0, make([]string, 0, decision.Max))
+	return moves
+}
+
+func distinctNames(cards []*Card) []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, card := range cards {
+		if !seen[card.Name] {
+			seen[card.Name] = true
+			names = append(names, card.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/quartermeat/card_game/gamestates"
+)
+
+// hasMove checks if a move is among the legal moves by its description
+func hasMove(moves []Move, description string) bool {
+	for _, move := range moves {
+		if move.String() == description {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLegalMovesOfTurn(t *testing.T) {
+	game := newTestGame(t)
+	player := game.Players[0]
+	player.Hand.Cards = nil
+	giveCard(game, player, "reload")
+	giveCard(game, player, "reload")
+	giveCard(game, player, "bullet")
+	giveCard(game, player, "slug")
+
+	moves := game.LegalMoves()
+	for _, expected := range []string{"play reload", "buy slug", "buy bullet", "end_turn"} {
+		if !hasMove(moves, expected) {
+			t.Errorf("expected %s among %v", expected, moves)
+		}
+	}
+	if len(moves) != 1+6+1 {
+		t.Errorf("expected reload once and the 6 piles costing up to 3, got %v", moves)
+	}
+
+	if err := game.Apply(Move{Kind: PLAY, Card: "reload"}); err != nil {
+		t.Fatal(err)
+	}
+	if hasMove(game.LegalMoves(), "play reload") {
+		t.Error("expected no more plays without actions")
+	}
+	if err := game.Apply(Move{Kind: BUY, Card: "slug"}); err != nil {
+		t.Fatal(err)
+	}
+	if game.StateManager.GetPhase() != gamestates.BuyPhase || player.Discard.Top().Name != "slug" {
+		t.Error("expected buying to play treasures and gain the slug")
+	}
+	if err := game.Apply(Move{Kind: END_TURN}); err != nil {
+		t.Fatal(err)
+	}
+	if game.Chooser() != 1 {
+		t.Errorf("expected the next seat to choose, got %d", game.Chooser())
+	}
+}
+
+func TestAnswerMoves(t *testing.T) {
+	decision := &Decision{
+		Options: []Option{{Name: "bullet"}, {Name: "bullet"}, {Name: "zombies"}},
+		Min:     1,
+		Max:     2,
+	}
+	moves := answerMoves(decision)
+	for _, expected := range []string{"answer bullet", "answer bullet,bullet", "answer bullet,zombies", "answer zombies"} {
+		if !hasMove(moves, expected) {
+			t.Errorf("expected %s among %v", expected, moves)
+		}
+	}
+	if len(moves) != 4 {
+		t.Errorf("expected 4 different answers, got %v", moves)
+	}
+	picks, err := decision.Indexes([]string{"bullet", "zombies", "bullet"})
+	if err != nil || picks[0] != 0 || picks[1] != 2 || picks[2] != 1 {
+		t.Errorf("expected picks 0, 2 and 1, got %v %v", picks, err)
+	}
+	if _, err := decision.Indexes([]string{"zombies", "zombies"}); err == nil {
+		t.Error("expected picking a name more times than it is offered to fail")
+	}
+}
+
+func TestApplyAnswer(t *testing.T) {
+	game := newTestGame(t)
+	player := game.Players[0]
+	giveCard(game, player, "scavenger")
+	giveCard(game, player, "infection")
+
+	if err := game.Apply(Move{Kind: PLAY, Card: "scavenger"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.Apply(Move{Kind: END_TURN}); err != ErrDecisionPending {
+		t.Errorf("expected ErrDecisionPending, got %v", err)
+	}
+	if !hasMove(game.LegalMoves(), "answer ") {
+		t.Error("expected trashing nothing to be an answer")
+	}
+	if err := game.Apply(Move{Kind: ANSWER, Picks: []string{"infection"}}); err != nil {
+		t.Fatal(err)
+	}
+	if game.Trash.Top() == nil || game.Trash.Top().Name != "infection" {
+		t.Error("expected the infection to be trashed")
+	}
+	if game.Chooser() != 0 || !hasMove(game.LegalMoves(), "answer reload") {
+		t.Errorf("expected to choose a card to gain, got %v", game.LegalMoves())
+	}
+}
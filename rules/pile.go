@@ -0,0 +1,91 @@
+// Package rules is the headless model of the card game.
+// It holds every card and every zone of a game without any dependency on pixel,
+// so games can be run and tested on machines without a display.
+package rules
+
+import "math/rand"
+
+// Card is a single card in the game, it keeps its ID as it moves between zones
+type Card struct {
+	ID   int
+	Name string
+}
+
+// Pile is an ordered stack of cards, the last card is the top of the pile
+type Pile struct {
+	Name  string
+	Cards []*Card
+}
+
+// NewPile creates an empty pile
+func NewPile(name string) *Pile {
+	return &Pile{
+		Name:  name,
+		Cards: make([]*Card, 0),
+	}
+}
+
+// Len returns the number of cards in the pile
+func (pile *Pile) Len() int {
+	return len(pile.Cards)
+}
+
+// Top returns the top card of the pile without removing it, nil if the pile is empty
+func (pile *Pile) Top() *Card {
+	if len(pile.Cards) == 0 {
+		return nil
+	}
+	return pile.Cards[len(pile.Cards)-1]
+}
+
+// Push puts cards on top of the pile
+func (pile *Pile) Push(cards ...*Card) {
+	pile.Cards = append(pile.Cards, cards...)
+}
+
+// Pop removes and returns the top card of the pile, nil if the pile is empty
+func (pile *Pile) Pop() *Card {
+	if len(pile.Cards) == 0 {
+		return nil
+	}
+	card := pile.Cards[len(pile.Cards)-1]
+	pile.Cards = pile.Cards[:len(pile.Cards)-1]
+	return card
+}
+
+// Remove takes a specific card out of the pile, keeping the order of the rest
+func (pile *Pile) Remove(card *Card) bool {
+	for index, candidate := range pile.Cards {
+		if candidate == card {
+			pile.Cards = append(pile.Cards[:index], pile.Cards[index+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Contains checks if the card is in the pile
+func (pile *Pile) Contains(card *Card) bool {
+	for _, candidate := range pile.Cards {
+		if candidate == card {
+			return true
+		}
+	}
+	return false
+}
+
+// Shuffle randomizes the order of the pile using rng
+func (pile *Pile) Shuffle(rng *rand.Rand) {
+	rng.Shuffle(len(pile.Cards), func(i, j int) {
+		pile.Cards[i], pile.Cards[j] = pile.Cards[j], pile.Cards[i]
+	})
+}
+
+// MoveCard moves a card from one pile to the top of another
+func MoveCard(card *Card, from *Pile, to *Pile) bool {
+	if !from.Remove(card) {
+		return false
+	}
+	to.Push(card)
+	return true
+}
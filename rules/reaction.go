@@ -0,0 +1,41 @@
+package rules
+
+// reactionWindow asks every other player, in turn order, if they reveal the reactions in their hand
+// before an attack resolves
+func (game *GameState) reactionWindow(attacker int, attack *Card) []pendingEffect {
+	game.unaffected = make(map[int]bool)
+	reveals := make([]pendingEffect, 0)
+	for offset := 1; offset < len(game.Players); offset++ {
+		other := (attacker + offset) % len(game.Players)
+		for _, card := range game.Players[other].Hand.Cards {
+			if def := game.Definition(card); def != nil && def.Is(REACTION) && len(reactions(def)) > 0 {
+				reveals = append(reveals, pendingEffect{player: other, effect: Effect{Verb: reveal}, source: attack, reaction: card})
+			}
+		}
+	}
+	return reveals
+}
+
+// react resolves a revealed reaction, blocking the attack or queuing the reaction's effects to resolve first
+func (game *GameState) react(revealed pendingEffect) {
+	queued := make([]pendingEffect, 0)
+	for _, reaction := range reactions(game.Definition(revealed.reaction)) {
+		if reaction.Verb == BLOCK {
+			game.unaffected[revealed.player] = true
+			continue
+		}
+		queued = append(queued, pendingEffect{player: revealed.player, effect: reaction, source: revealed.reaction})
+	}
+	game.queue = append(queued, game.queue...)
+}
+
+// reactions returns what a reaction card does when it is revealed
+func reactions(def *CardDefinition) []Effect {
+	effects := make([]Effect, 0)
+	for _, effect := range def.Effects {
+		if effect.Verb == REACT {
+			effects = append(effects, *effect.Inner)
+		}
+	}
+	return effects
+}
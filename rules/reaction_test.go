@@ -0,0 +1,117 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+)
+
+// answerReveal answers a pending reveal decision for a player
+func answerReveal(t *testing.T, game *GameState, player int, reaction string, pick int) {
+	t.Helper()
+	decision := game.PendingDecision()
+	if decision == nil || decision.Kind != YES_NO || decision.Player != player || decision.Options[YES].Card.Name != reaction {
+		t.Fatalf("expected player %d to be asked to reveal %s, got %+v", player, reaction, decision)
+	}
+	if err := game.Answer([]int{pick}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReactionBlocksAttack(t *testing.T) {
+	game := newTestGame(t)
+	player, ai := game.Players[0], game.Players[1]
+	giveCard(game, ai, "barricade")
+
+	if err := game.PlayAction(player, giveCard(game, player, "zombie_swarm")); err != nil {
+		t.Fatal(err)
+	}
+	if player.Hand.Len() != HAND_SIZE {
+		t.Error("expected the attack to wait for the reaction before drawing")
+	}
+	answerReveal(t, game, 1, "barricade", YES)
+	if player.Hand.Len() != HAND_SIZE+2 {
+		t.Error("expected the attack to carry on for the attacker")
+	}
+	for _, card := range ai.Cards() {
+		if card.Name == "infection" {
+			t.Fatal("expected the revealed barricade to block the infection")
+		}
+	}
+	if ai.Hand.Len() != HAND_SIZE+1 {
+		t.Error("expected the barricade to stay in hand")
+	}
+}
+
+func TestDecliningReactionTakesAttack(t *testing.T) {
+	game := newTestGame(t)
+	player, ai := game.Players[0], game.Players[1]
+	giveCard(game, ai, "barricade")
+
+	game.PlayAction(player, giveCard(game, player, "zombie_swarm"))
+	answerReveal(t, game, 1, "barricade", NO)
+	if ai.Discard.Top() == nil || ai.Discard.Top().Name != "infection" {
+		t.Error("expected the ai to gain an infection")
+	}
+
+	// the block only lasts for the attack it was revealed to
+	game.StateManager.AddActions(1)
+	game.PlayAction(player, giveCard(game, player, "zombie_swarm"))
+	answerReveal(t, game, 1, "barricade", YES)
+	game.StateManager.AddActions(1)
+	game.PlayAction(player, giveCard(game, player, "molotov_cocktail"))
+	answerReveal(t, game, 1, "barricade", NO)
+	if decision := game.PendingDecision(); decision == nil || decision.Kind != CHOOSE_CARDS || decision.Player != 1 {
+		t.Errorf("expected the next attack to make the ai discard, got %+v", decision)
+	}
+}
+
+func TestReactionEffectResolvesBeforeAttack(t *testing.T) {
+	game := newTestGame(t)
+	player, ai := game.Players[0], game.Players[1]
+	giveCard(game, ai, "decoy")
+
+	game.PlayAction(player, giveCard(game, player, "molotov_cocktail"))
+	answerReveal(t, game, 1, "decoy", YES)
+	decision := game.PendingDecision()
+	if decision == nil || decision.Player != 1 || decision.Min != HAND_SIZE+2-3 {
+		t.Fatalf("expected the ai to draw a card and then discard down to 3, got %+v", decision)
+	}
+}
+
+func TestReactionWindowInTurnOrder(t *testing.T) {
+	game := newTestGame(t)
+	game.AddPlayer("third", false)
+	player := game.Players[0]
+	for _, other := range game.Players[1:] {
+		giveCard(game, other, "barricade")
+	}
+	// a decider answers its seat's reveal without stopping
+	revealed := 0
+	game.SetDecider(2, DeciderFunc(func(game *GameState, decision *Decision) []int {
+		revealed++
+		return []int{YES}
+	}))
+
+	game.PlayAction(player, giveCard(game, player, "zombie_swarm"))
+	answerReveal(t, game, 1, "barricade", NO)
+	if revealed != 1 || game.PendingDecision() != nil {
+		t.Fatalf("expected the third seat to be asked after the second, asked %d times", revealed)
+	}
+	if game.Players[1].Discard.Top().Name != "infection" || game.Players[2].Discard.Len() != 0 {
+		t.Error("expected only the player who didn't react to gain an infection")
+	}
+}
+
+func TestReactionsOnlyForAttacks(t *testing.T) {
+	game := newTestGame(t)
+	player, ai := game.Players[0], game.Players[1]
+	giveCard(game, ai, "barricade")
+
+	game.PlayAction(player, giveCard(game, player, "reload"))
+	if game.PendingDecision() != nil {
+		t.Error("expected no reaction window for a card that isn't an attack")
+	}
+	if _, err := ReadCardDefinitions(strings.NewReader("hide,2,action,0,0,react block")); err == nil {
+		t.Error("expected a card that reacts without being a reaction to fail")
+	}
+}
@@ -0,0 +1,80 @@
+package rules
+
+import (
+	"sort"
+
+	"github.com/quartermeat/card_game/gamestates"
+)
+
+// the game ends once this many supply piles are empty
+const empty_piles_to_end = 3
+
+// Standing is a player's final result
+type Standing struct {
+	Player *Player
+	Score  int
+	Turns  int
+}
+
+// IsOver checks the end conditions: the even_more_zombies pile or any three supply piles running out
+func (game *GameState) IsOver() bool {
+	if pile := game.SupplyPile(EVEN_MORE_ZOMBIES); pile != nil && pile.Len() == 0 {
+		return true
+	}
+	empty := 0
+	for _, pile := range game.Supply {
+		if pile.Len() == 0 {
+			empty++
+		}
+	}
+	return empty >= empty_piles_to_end
+}
+
+// Score tallies the victory points of every card a player owns, curses count against them
+func (game *GameState) Score(player *Player) int {
+	score := 0
+	for _, card := range player.Cards() {
+		if def := game.Definition(card); def != nil {
+			score += def.VP
+		}
+	}
+	return score
+}
+
+// Standings ranks the players by score, ties going to the player who took fewer turns
+func (game *GameState) Standings() []Standing {
+	standings := make([]Standing, 0, len(game.Players))
+	for _, player := range game.Players {
+		standings = append(standings, Standing{Player: player, Score: game.Score(player), Turns: player.Turns})
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Score != standings[j].Score {
+			return standings[i].Score > standings[j].Score
+		}
+		return standings[i].Turns < standings[j].Turns
+	})
+	return standings
+}
+
+// Winners returns the players sharing first place
+func (game *GameState) Winners() []*Player {
+	standings := game.Standings()
+	winners := make([]*Player, 0, 1)
+	for _, standing := range standings {
+		if standing.Score != standings[0].Score || standing.Turns != standings[0].Turns {
+			break
+		}
+		winners = append(winners, standing.Player)
+	}
+	return winners
+}
+
+// endTurn passes the turn to the next player, or ends the game if an end condition has been met
+func (game *GameState) endTurn() {
+	if game.IsOver() {
+		game.StateManager.SetCurrentState(gamestates.GameOver)
+		return
+	}
+	game.Current = (game.Current + 1) % len(game.Players)
+	game.startTurn()
+}
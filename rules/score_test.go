@@ -0,0 +1,84 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/quartermeat/card_game/gamestates"
+)
+
+// finishTurn ends phases until the turn passes or the game is over
+func finishTurn(game *GameState) {
+	current := game.Current
+	for game.Current == current && game.StateManager.IsTurn() {
+		game.EndPhase()
+	}
+}
+
+func TestScoreCountsVictoryAndCurses(t *testing.T) {
+	game := newTestGame(t)
+	player := game.Players[0]
+
+	if score := game.Score(player); score != starting_zombies {
+		t.Errorf("expected the starting deck to score %d, got %d", starting_zombies, score)
+	}
+	player.Discard.Push(game.NewCard("infection"), game.NewCard("infection"))
+	if score := game.Score(player); score != starting_zombies-2 {
+		t.Errorf("expected infections to count against the score, got %d", score)
+	}
+}
+
+func TestEndConditions(t *testing.T) {
+	game := newTestGame(t)
+	if game.IsOver() {
+		t.Fatal("a new game should not be over")
+	}
+	for _, name := range []string{"reload", "scavenger"} {
+		game.SupplyPile(name).Cards = nil
+	}
+	if game.IsOver() {
+		t.Fatal("two empty piles should not end the game")
+	}
+	game.SupplyPile("slug").Cards = nil
+	if !game.IsOver() {
+		t.Fatal("three empty piles should end the game")
+	}
+
+	game = newTestGame(t)
+	game.AddSupplyPile(EVEN_MORE_ZOMBIES, 0)
+	if !game.IsOver() {
+		t.Fatal("an empty even_more_zombies pile should end the game")
+	}
+}
+
+func TestGameOverAfterTurnEnds(t *testing.T) {
+	game := newTestGame(t)
+	player, ai := game.Players[0], game.Players[1]
+
+	finishTurn(game)
+	if game.CurrentPlayer() != ai {
+		t.Fatal("expected the ai's turn")
+	}
+	for _, name := range []string{"reload", "scavenger", "slug"} {
+		game.SupplyPile(name).Cards = nil
+	}
+	finishTurn(game)
+	if game.StateManager.GetCurrentState() != gamestates.GameOver {
+		t.Fatalf("expected the game to be over, state %d", game.StateManager.GetCurrentState())
+	}
+	if _, err := game.EndPhase(); err != ErrNotYourTurn {
+		t.Errorf("expected no more turns, got %v", err)
+	}
+
+	// both players have the starting deck and the same number of turns
+	if winners := game.Winners(); len(winners) != 2 {
+		t.Errorf("expected a shared win, got %d winners", len(winners))
+	}
+	player.Turns++
+	if winners := game.Winners(); len(winners) != 1 || winners[0] != ai {
+		t.Error("expected the player with fewer turns to win the tie")
+	}
+	ai.Discard.Push(game.NewCard("infection"))
+	if standings := game.Standings(); standings[0].Player != player || standings[1].Score != starting_zombies-1 {
+		t.Errorf("expected the higher score to win, got %+v", standings)
+	}
+}
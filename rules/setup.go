@@ -0,0 +1,96 @@
+package rules
+
+import "github.com/pkg/errors"
+
+const (
+	MIN_PLAYERS = 2
+	MAX_PLAYERS = 4
+)
+
+// PileSize is how many cards of a kind go in a pile
+type PileSize struct {
+	Name  string
+	Count int
+}
+
+// Setup is the recipe the supply is built from for any number of players
+type Setup struct {
+	// Treasures are the treasure piles before the cards of every starting deck are taken out of them
+	Treasures []PileSize
+	// Victory are the victory card piles, sized by the number of players
+	Victory []string
+	// VictoryTwoPlayers is the size of the victory piles with 2 players, VictoryMorePlayers with 3 or 4
+	VictoryTwoPlayers  int
+	VictoryMorePlayers int
+	// KingdomPileSize is the size of the piles of the kingdom cards that aren't victory cards
+	KingdomPileSize int
+}
+
+// DefaultSetup is the supply of the standard rules
+var DefaultSetup = Setup{
+	Treasures:          []PileSize{{BULLET, 80}, {"slug", 70}, {"shells", 48}},
+	Victory:            []string{ZOMBIES, "more_zombies", EVEN_MORE_ZOMBIES},
+	VictoryTwoPlayers:  8,
+	VictoryMorePlayers: 12,
+	KingdomPileSize:    10,
+}
+
+// startingDeck is the deck every player starts with
+var startingDeck = []PileSize{{ZOMBIES, starting_zombies}, {BULLET, starting_bullets}}
+
+// Piles returns the supply piles for a game of players with the kingdom, in the order they are laid out:
+// treasures, victory cards, infections, then the kingdom
+func (setup Setup) Piles(players int, kingdom []string, definitions CardDefinitions) ([]PileSize, error) {
+	if players < MIN_PLAYERS || players > MAX_PLAYERS {
+		return nil, errors.Errorf("games are for %d to %d players, not %d", MIN_PLAYERS, MAX_PLAYERS, players)
+	}
+	victorySize := setup.VictoryTwoPlayers
+	if players > 2 {
+		victorySize = setup.VictoryMorePlayers
+	}
+
+	piles := make([]PileSize, 0, len(setup.Treasures)+len(setup.Victory)+1+len(kingdom))
+	for _, treasure := range setup.Treasures {
+		count := treasure.Count
+		for _, starting := range startingDeck {
+			if starting.Name == treasure.Name {
+				count -= starting.Count * players
+			}
+		}
+		if count < 0 {
+			return nil, errors.Errorf("not enough %s for %d starting decks", treasure.Name, players)
+		}
+		piles = append(piles, PileSize{treasure.Name, count})
+	}
+	for _, name := range setup.Victory {
+		piles = append(piles, PileSize{name, victorySize})
+	}
+	piles = append(piles, PileSize{INFECTION, InfectionPileSize(players)})
+	for _, name := range kingdom {
+		def, ok := definitions[name]
+		if !ok {
+			return nil, errors.Errorf("%s has no definition", name)
+		}
+		count := setup.KingdomPileSize
+		if def.Is(VICTORY) {
+			count = victorySize
+		}
+		piles = append(piles, PileSize{name, count})
+	}
+	return piles, nil
+}
+
+// SetupSupply fills the supply for a game of players using the kingdom already chosen
+func (game *GameState) SetupSupply(setup Setup, players int) error {
+	if len(game.Kingdom) == 0 {
+		return errors.New("choose the kingdom before setting up the supply")
+	}
+	piles, err := setup.Piles(players, game.Kingdom, game.Definitions)
+	if err != nil {
+		return err
+	}
+	for _, pile := range piles {
+		game.AddSupplyPile(pile.Name, pile.Count)
+	}
+	return nil
+}
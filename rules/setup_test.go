@@ -0,0 +1,54 @@
+package rules
+
+import "testing"
+
+func TestSupplyFollowsPlayerCount(t *testing.T) {
+	game := newShippedGame(t, 1)
+	kingdom := KingdomPresets["first_night"]
+
+	for players, expected := range map[int]map[string]int{
+		2: {BULLET: 80 - 2*starting_bullets, "shells": 48, ZOMBIES: 8, EVEN_MORE_ZOMBIES: 8, INFECTION: 10, "hide": 10},
+		3: {BULLET: 80 - 3*starting_bullets, ZOMBIES: 12, "more_zombies": 12, INFECTION: 20},
+		4: {BULLET: 80 - 4*starting_bullets, "slug": 70, EVEN_MORE_ZOMBIES: 12, INFECTION: 30, "reload": 10},
+	} {
+		piles, err := DefaultSetup.Piles(players, kingdom, game.Definitions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(piles) != 7+KINGDOM_SIZE {
+			t.Fatalf("expected %d piles, got %d", 7+KINGDOM_SIZE, len(piles))
+		}
+		sizes := make(map[string]int)
+		for _, pile := range piles {
+			sizes[pile.Name] = pile.Count
+		}
+		for name, count := range expected {
+			if sizes[name] != count {
+				t.Errorf("%d players: expected %d %s, got %d", players, count, name, sizes[name])
+			}
+		}
+	}
+
+	for _, players := range []int{1, 5} {
+		if _, err := DefaultSetup.Piles(players, kingdom, game.Definitions); err == nil {
+			t.Errorf("expected %d players to be rejected", players)
+		}
+	}
+}
+
+func TestSetupSupply(t *testing.T) {
+	game := newShippedGame(t, 1)
+	if err := game.SetupSupply(DefaultSetup, 2); err == nil {
+		t.Error("expected setting up without a kingdom to fail")
+	}
+	game.ChooseKingdom(DefaultKingdomConfig())
+	if err := game.SetupSupply(DefaultSetup, 3); err != nil {
+		t.Fatal(err)
+	}
+	if pile := game.SupplyPile(game.Kingdom[0]); pile == nil || pile.Len() != DefaultSetup.KingdomPileSize {
+		t.Error("expected a pile for every kingdom card")
+	}
+	if game.SupplyPile(INFECTION).Len() != 20 {
+		t.Error("expected 10 infections for each of two opponents")
+	}
+}
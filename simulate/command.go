@@ -0,0 +1,250 @@
+package simulate
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/quartermeat/card_game/rules"
+)
+
+// batchFlags are the flags of commands that play a batch of games
+type batchFlags struct {
+	seats   *string
+	games   *int
+	seed    *int64
+	workers *int
+	kingdom *string
+	cards   *string
+}
+
+// addBatchFlags adds the flags saying which games to play to a command's flags
+func addBatchFlags(flags *flag.FlagSet, seats string, games int) *batchFlags {
+	return &batchFlags{
+		seats:   flags.String("seats", seats, "comma separated strategy for each seat in turn order, like random, big_money:reload, priority:<file> or mcts:200"),
+		games:   flags.Int("games", games, "number of games to play"),
+		seed:    flags.Int64("seed", 1, "seed of the first game, the games after it use the seeds after it"),
+		workers: flags.Int("workers", 0, "games to play at once, one per CPU if 0"),
+		kingdom: flags.String("kingdom", "", "kingdom preset or comma separated list of kingdom cards, a random kingdom each game if not set"),
+		cards:   flags.String("cards", "assets/cards/cardDefinitions.csv", "card definitions file"),
+	}
+}
+
+// config loads the card definitions and builds the config of the batch
+func (batch *batchFlags) config() (Config, error) {
+	definitions, err := rules.LoadCardDefinitions(*batch.cards)
+	if err != nil {
+		return Config{}, err
+	}
+	return Config{
+		Definitions: definitions,
+		Seats:       splitNames(*batch.seats),
+		Kingdom:     rules.ParseKingdomConfig(*batch.kingdom),
+		Games:       *batch.games,
+		Seed:        *batch.seed,
+		Workers:     *batch.workers,
+	}, nil
+}
+
+// outputFile returns the file named by an -out flag, stdout if it wasn't set
+func outputFile(out string, stdout io.Writer) (io.Writer, func() error, error) {
+	if out == "" {
+		return stdout, func() error { return nil }, nil
+	}
+	file, err := os.Create(out)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error writing %s", out)
+	}
+	return file, file.Close, nil
+}
+
+// Main runs the simulate command with its command line arguments, writing the stats to stdout
+// unless -out names a file
+func Main(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	batch := addBatchFlags(flags, "big_money,big_money", 1000)
+	format := flags.String("format", "csv", "csv or json")
+	out := flags.String("out", "", "file to write the stats to, stdout if not set")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *format != "csv" && *format != "json" {
+		return errors.Errorf("-format is csv or json, not %q", *format)
+	}
+
+	config, err := batch.config()
+	if err != nil {
+		return err
+	}
+	results, err := Run(config)
+	if err != nil {
+		return err
+	}
+	stats := Summarize(config.Seats, results)
+
+	writer, done, err := outputFile(*out, stdout)
+	if err != nil {
+		return err
+	}
+	defer done()
+	if *format == "json" {
+		return stats.WriteJSON(writer)
+	}
+	return stats.WriteCSV(writer)
+}
+
+// ReportMain runs the report command with its command line arguments, writing the balance report
+// of every kingdom card to stdout unless -out names a file
+func ReportMain(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	batch := addBatchFlags(flags, "random,random", 2000)
+	format := flags.String("format", "markdown", "markdown or csv")
+	out := flags.String("out", "", "file to write the report to, stdout if not set")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *format != "markdown" && *format != "csv" {
+		return errors.Errorf("-format is markdown or csv, not %q", *format)
+	}
+
+	config, err := batch.config()
+	if err != nil {
+		return err
+	}
+	results, err := Run(config)
+	if err != nil {
+		return err
+	}
+	report := CardReport(config.Definitions, results)
+
+	writer, done, err := outputFile(*out, stdout)
+	if err != nil {
+		return err
+	}
+	defer done()
+	if *format == "csv" {
+		return WriteCardReportCSV(writer, report)
+	}
+	return WriteCardReportMarkdown(writer, config.Seats, len(results), Summarize(config.Seats, results).Unfinished, report)
+}
+
+// TournamentMain runs the tournament command with its command line arguments, updating the Elo
+// table file and writing the leaderboard file, then printing the leaderboard to stdout
+func TournamentMain(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("tournament", flag.ContinueOnError)
+	var (
+		bots        = flags.String("bots", "", "comma separated strategies to enter, every registered strategy that needs no argument if not set")
+		games       = flags.Int("games", 50, "seeds each pair plays, every seed is played with both seat orders")
+		seed        = flags.Int64("seed", 1, "seed of the first game, the games after it use the seeds after it")
+		workers     = flags.Int("workers", 0, "games to play at once, one per CPU if 0")
+		kingdom     = flags.String("kingdom", "", "kingdom preset or comma separated list of kingdom cards, a random kingdom each game if not set")
+		elo         = flags.String("elo", "elo.csv", "elo table file, kept between tournaments")
+		leaderboard = flags.String("leaderboard", "leaderboard.md", "leaderboard file to write")
+		cards       = flags.String("cards", "assets/cards/cardDefinitions.csv", "card definitions file")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	definitions, err := rules.LoadCardDefinitions(*cards)
+	if err != nil {
+		return err
+	}
+	table, err := LoadEloTable(*elo)
+	if err != nil {
+		return err
+	}
+	config := TournamentConfig{
+		Definitions: definitions,
+		Entrants:    splitNames(*bots),
+		Kingdom:     rules.ParseKingdomConfig(*kingdom),
+		Games:       *games,
+		Seed:        *seed,
+		Workers:     *workers,
+	}
+	if len(config.Entrants) == 0 {
+		config.Entrants = Entrants(definitions)
+	}
+	matches, err := Tournament(config, table)
+	if err != nil {
+		return err
+	}
+	if err := table.Save(*elo); err != nil {
+		return err
+	}
+	if err := SaveLeaderboard(*leaderboard, table, matches); err != nil {
+		return err
+	}
+	return WriteLeaderboard(stdout, table, matches)
+}
+
+// TuneMain runs the tune command with its command line arguments, printing the progress of each
+// generation to stdout and saving the best priority list found to the -out file
+func TuneMain(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("tune", flag.ContinueOnError)
+	var (
+		kingdom     = flags.String("kingdom", "first_night", "kingdom preset or comma separated list of kingdom cards to tune for")
+		against     = flags.String("against", "big_money", "strategy the candidates play against")
+		population  = flags.Int("population", 24, "priority lists in each generation")
+		generations = flags.Int("generations", 20, "generations to evolve")
+		games       = flags.Int("games", 40, "games each priority list plays in a generation")
+		seed        = flags.Int64("seed", 1, "seed of the tuner and its games")
+		workers     = flags.Int("workers", 0, "games to play at once, one per CPU if 0")
+		out         = flags.String("out", "assets/bots/tuned.txt", "file to save the best priority list to, load it with -seats human,priority:<file>")
+		cards       = flags.String("cards", "assets/cards/cardDefinitions.csv", "card definitions file")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *kingdom == "" {
+		return errors.New("-kingdom is needed, lists are tuned for a chosen kingdom")
+	}
+
+	definitions, err := rules.LoadCardDefinitions(*cards)
+	if err != nil {
+		return err
+	}
+	config := TuneConfig{
+		Definitions: definitions,
+		Kingdom:     rules.ParseKingdomConfig(*kingdom),
+		Reference:   *against,
+		Population:  *population,
+		Generations: *generations,
+		Games:       *games,
+		Seed:        *seed,
+		Workers:     *workers,
+		Progress: func(generation int, best Candidate) {
+			fmt.Fprintf(stdout, "generation %d: best won %.1f%%, by %+.1f victory points a game\n", generation+1, 100*best.Fitness, best.Margin)
+		},
+	}
+	best, err := Tune(config)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		return errors.Wrap(err, "error saving priority list")
+	}
+	defer file.Close()
+	fmt.Fprintf(file, "# tuned against %s on the %s kingdom, seed %d\n", *against, *kingdom, *seed)
+	fmt.Fprintf(file, "# won %.1f%% of %d games in the last generation\n", 100*best.Fitness, *games)
+	if err := best.List.Write(file); err != nil {
+		return errors.Wrap(err, "error saving priority list")
+	}
+	fmt.Fprintf(stdout, "saved to %s\n", *out)
+	return nil
+}
+
+func splitNames(list string) []string {
+	names := make([]string, 0)
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,86 @@
+package simulate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/quartermeat/card_game/rules"
+	"github.com/quartermeat/card_game/strategy"
+)
+
+func TestMainWritesStats(t *testing.T) {
+	var out bytes.Buffer
+	err := Main([]string{"-cards", "../assets/cards/cardDefinitions.csv", "-games", "4", "-kingdom", "first_night", "-format", "json"}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `"games": 4`) {
+		t.Errorf("expected json stats of 4 games, got %s", out.String())
+	}
+	if err := Main([]string{"-format", "xml"}, &out); err == nil {
+		t.Error("expected an unknown format to fail")
+	}
+}
+
+func TestTournamentMainKeepsElo(t *testing.T) {
+	dir := t.TempDir()
+	args := []string{
+		"-cards", "../assets/cards/cardDefinitions.csv",
+		"-bots", "random,big_money",
+		"-games", "2",
+		"-elo", filepath.Join(dir, "elo.csv"),
+		"-leaderboard", filepath.Join(dir, "leaderboard.md"),
+	}
+	var out bytes.Buffer
+	for run := 0; run < 2; run++ {
+		if err := TournamentMain(args, &out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	table, err := LoadEloTable(filepath.Join(dir, "elo.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table["random"] == nil || table["random"].Games != 8 {
+		t.Errorf("expected the elo table to build up over both tournaments, got %+v", table["random"])
+	}
+	if page, err := os.ReadFile(filepath.Join(dir, "leaderboard.md")); err != nil || !strings.HasPrefix(string(page), "# Leaderboard") {
+		t.Errorf("expected a leaderboard file, got %q %v", page, err)
+	}
+}
+
+func TestTuneMainSavesALoadableList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tuned.txt")
+	var out bytes.Buffer
+	args := []string{"-cards", "../assets/cards/cardDefinitions.csv", "-population", "4", "-generations", "2", "-games", "4", "-out", path}
+	if err := TuneMain(args, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "generation 2: best won") {
+		t.Errorf("expected progress for each generation, got %s", out.String())
+	}
+	defs, err := rules.LoadCardDefinitions("../assets/cards/cardDefinitions.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := strategy.New("priority:"+path, defs); err != nil {
+		t.Errorf("expected the saved list to load as a strategy, got %v", err)
+	}
+}
+
+func TestReportMain(t *testing.T) {
+	var out bytes.Buffer
+	args := []string{"-cards", "../assets/cards/cardDefinitions.csv", "-games", "20", "-kingdom", "outbreak", "-format", "csv"}
+	if err := ReportMain(args, &out); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 1+10 {
+		t.Errorf("expected a row for each of the 10 kingdom cards, got\n%s", out.String())
+	}
+	if err := ReportMain([]string{"-format", "pdf"}, &out); err == nil {
+		t.Error("expected an unknown format to fail")
+	}
+}
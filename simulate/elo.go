@@ -0,0 +1,155 @@
+package simulate
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ELO_START is the rating of a strategy that hasn't played yet
+	ELO_START = 1500
+	// ELO_K is the most a rating moves after a single game
+	ELO_K = 32
+)
+
+// Rating is a strategy's line in the Elo table
+type Rating struct {
+	Strategy string
+	Elo      float64
+	Games    int
+	Wins     int
+	Draws    int
+	Losses   int
+}
+
+// EloTable holds the ratings of strategies by spec, kept in a csv file between tournaments
+type EloTable map[string]*Rating
+
+// LoadEloTable reads the Elo table file at path, a missing file is an empty table
+func LoadEloTable(path string) (EloTable, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return make(EloTable), nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading elo table")
+	}
+	defer file.Close()
+
+	table, err := ReadEloTable(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading elo table from %s", path)
+	}
+	return table, nil
+}
+
+// ReadEloTable reads an Elo table in the csv format written by Write
+func ReadEloTable(reader io.Reader) (EloTable, error) {
+	table := make(EloTable)
+	rows := csv.NewReader(reader)
+	rows.FieldsPerRecord = 6
+	if _, err := rows.Read(); err != nil && err != io.EOF {
+		return nil, err
+	}
+	for {
+		row, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rating := &Rating{Strategy: row[0]}
+		if rating.Elo, err = strconv.ParseFloat(row[1], 64); err != nil {
+			return nil, errors.Wrapf(err, "elo of %s", rating.Strategy)
+		}
+		counts := []*int{&rating.Games, &rating.Wins, &rating.Draws, &rating.Losses}
+		for index, count := range counts {
+			if *count, err = strconv.Atoi(row[2+index]); err != nil {
+				return nil, errors.Wrapf(err, "games of %s", rating.Strategy)
+			}
+		}
+		table[rating.Strategy] = rating
+	}
+	return table, nil
+}
+
+// Write writes the table as csv, best rating first
+func (table EloTable) Write(writer io.Writer) error {
+	rows := csv.NewWriter(writer)
+	rows.Write([]string{"strategy", "elo", "games", "wins", "draws", "losses"})
+	for _, rating := range table.Ranked() {
+		rows.Write([]string{
+			rating.Strategy,
+			strconv.FormatFloat(rating.Elo, 'f', 1, 64),
+			strconv.Itoa(rating.Games),
+			strconv.Itoa(rating.Wins),
+			strconv.Itoa(rating.Draws),
+			strconv.Itoa(rating.Losses),
+		})
+	}
+	rows.Flush()
+	return rows.Error()
+}
+
+// Save writes the table to the file at path
+func (table EloTable) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "error saving elo table")
+	}
+	defer file.Close()
+	return table.Write(file)
+}
+
+// Ranked returns the ratings from best to worst
+func (table EloTable) Ranked() []*Rating {
+	ranked := make([]*Rating, 0, len(table))
+	for _, rating := range table {
+		ranked = append(ranked, rating)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Elo != ranked[j].Elo {
+			return ranked[i].Elo > ranked[j].Elo
+		}
+		return ranked[i].Strategy < ranked[j].Strategy
+	})
+	return ranked
+}
+
+// rating returns a strategy's rating, adding it at ELO_START if it is new
+func (table EloTable) rating(strategy string) *Rating {
+	if table[strategy] == nil {
+		table[strategy] = &Rating{Strategy: strategy, Elo: ELO_START}
+	}
+	return table[strategy]
+}
+
+// Record updates the ratings of two strategies after a game between them,
+// score is 1 if the first won, 0.5 for a draw and 0 if the second won
+func (table EloTable) Record(first string, second string, score float64) {
+	one, two := table.rating(first), table.rating(second)
+	expected := 1 / (1 + math.Pow(10, (two.Elo-one.Elo)/400))
+	one.Elo += ELO_K * (score - expected)
+	two.Elo -= ELO_K * (score - expected)
+	one.count(score)
+	two.count(1 - score)
+}
+
+func (rating *Rating) count(score float64) {
+	rating.Games++
+	switch score {
+	case 1:
+		rating.Wins++
+	case 0:
+		rating.Losses++
+	default:
+		rating.Draws++
+	}
+}
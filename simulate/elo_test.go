@@ -0,0 +1,54 @@
+package simulate
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestEloRecord(t *testing.T) {
+	table := make(EloTable)
+	table.Record("big_money", "random", 1)
+	if table["big_money"].Elo != ELO_START+ELO_K/2 || table["random"].Elo != ELO_START-ELO_K/2 {
+		t.Errorf("expected an even game to move both ratings by half of K, got %v and %v", table["big_money"].Elo, table["random"].Elo)
+	}
+	before := table["big_money"].Elo
+	table.Record("big_money", "random", 1)
+	if gain := table["big_money"].Elo - before; gain <= 0 || gain >= ELO_K/2 {
+		t.Errorf("expected beating a weaker strategy to gain less, gained %v", gain)
+	}
+	table.Record("random", "big_money", 0.5)
+	if rating := table["random"]; rating.Games != 3 || rating.Losses != 2 || rating.Draws != 1 {
+		t.Errorf("expected 2 losses and a draw, got %+v", rating)
+	}
+	if ranked := table.Ranked(); ranked[0].Strategy != "big_money" {
+		t.Errorf("expected big_money to rank first, got %s", ranked[0].Strategy)
+	}
+}
+
+func TestEloTableRoundTrip(t *testing.T) {
+	table := make(EloTable)
+	table.Record("mcts", "random", 1)
+	table.Record("mcts", "big_money", 0.5)
+
+	var text bytes.Buffer
+	if err := table.Write(&text); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadEloTable(&text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, rating := range table {
+		if got := read[name]; got == nil || got.Games != rating.Games || got.Wins != rating.Wins || got.Elo-rating.Elo > 0.1 || rating.Elo-got.Elo > 0.1 {
+			t.Errorf("expected %+v, got %+v", rating, got)
+		}
+	}
+}
+
+func TestLoadMissingEloTable(t *testing.T) {
+	table, err := LoadEloTable(filepath.Join(t.TempDir(), "elo.csv"))
+	if err != nil || len(table) != 0 {
+		t.Errorf("expected a missing file to be an empty table, got %v %v", table, err)
+	}
+}
@@ -0,0 +1,196 @@
+package simulate
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/quartermeat/card_game/rules"
+)
+
+// REPORT_COPIES is the most copies of a card the report counts separately, more are counted with it
+const REPORT_COPIES = 4
+
+// CardStats is how the seats that could buy a kingdom card did, by whether and how much they bought it
+type CardStats struct {
+	Card string
+	// Offered is how many times a seat played a game with the card in the kingdom
+	Offered int
+	// Bought is how many of those times the seat bought at least one
+	Bought           int
+	WinRateBought    float64
+	WinRateNotBought float64
+	// AverageBuyTurn is the average turn a copy was bought on
+	AverageBuyTurn float64
+	// ByCopies is how the seats did by the number of copies they bought, up to REPORT_COPIES or more
+	ByCopies [REPORT_COPIES + 1]CopyStats
+}
+
+// Difference is how much more often a seat won when it bought the card
+func (stats CardStats) Difference() float64 {
+	return stats.WinRateBought - stats.WinRateNotBought
+}
+
+// CopyStats is how often the seats that bought a number of copies won
+type CopyStats struct {
+	Games   int
+	WinRate float64
+}
+
+// CardReport works out the stats of every kingdom card in the results, the cards that helped
+// most first. A shared first place counts as a fraction of a win, and games given up on at the
+// TURN_LIMIT are left out since nobody won them.
+func CardReport(definitions rules.CardDefinitions, results []Result) []CardStats {
+	stats := make(map[string]*CardStats)
+	wins := make(map[string]*[REPORT_COPIES + 1]float64)
+	// the turns every copy was bought on added up, and the number of copies
+	buyTurns := make(map[string]int)
+	copiesBought := make(map[string]int)
+	for _, result := range results {
+		if !result.Finished {
+			continue
+		}
+		for seat := range result.Scores {
+			won := share(result, seat)
+			for _, card := range result.Kingdom {
+				if stats[card] == nil {
+					stats[card] = &CardStats{Card: card}
+					wins[card] = &[REPORT_COPIES + 1]float64{}
+				}
+				copies := result.Buys[seat][card]
+				if copies > REPORT_COPIES {
+					copies = REPORT_COPIES
+				}
+				stats[card].Offered++
+				stats[card].ByCopies[copies].Games++
+				wins[card][copies] += won
+				for _, turn := range result.BuyTurns[seat][card] {
+					buyTurns[card] += turn
+					copiesBought[card]++
+				}
+			}
+		}
+	}
+
+	report := make([]CardStats, 0, len(stats))
+	for card, cardStats := range stats {
+		if def := definitions[card]; def == nil || !rules.IsKingdomCard(def) {
+			continue
+		}
+		boughtWins := 0.0
+		for count := range cardStats.ByCopies {
+			if count > 0 {
+				cardStats.Bought += cardStats.ByCopies[count].Games
+				boughtWins += wins[card][count]
+			}
+			if games := cardStats.ByCopies[count].Games; games > 0 {
+				cardStats.ByCopies[count].WinRate = wins[card][count] / float64(games)
+			}
+		}
+		if cardStats.Bought > 0 {
+			cardStats.WinRateBought = boughtWins / float64(cardStats.Bought)
+			cardStats.AverageBuyTurn = float64(buyTurns[card]) / float64(copiesBought[card])
+		}
+		cardStats.WinRateNotBought = cardStats.ByCopies[0].WinRate
+		report = append(report, *cardStats)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Difference() != report[j].Difference() {
+			return report[i].Difference() > report[j].Difference()
+		}
+		return report[i].Card < report[j].Card
+	})
+	return report
+}
+
+// share is a seat's share of the win of a game
+func share(result Result, seat int) float64 {
+	for _, winner := range result.Winners {
+		if winner == seat {
+			return 1 / float64(len(result.Winners))
+		}
+	}
+	return 0
+}
+
+// copiesLabel names a ByCopies bucket, like "2" or "4+"
+func copiesLabel(copies int) string {
+	if copies == REPORT_COPIES {
+		return strconv.Itoa(copies) + "+"
+	}
+	return strconv.Itoa(copies)
+}
+
+// WriteCardReportMarkdown writes the report as a markdown page, seats are the strategies that played
+// and unfinished is how many of the games were left out of the report
+func WriteCardReportMarkdown(writer io.Writer, seats []string, games int, unfinished int, report []CardStats) error {
+	fmt.Fprintln(writer, "# Card balance")
+	fmt.Fprintln(writer)
+	fmt.Fprintf(writer, "From %d games between %s", games, strings.Join(seats, ", "))
+	if unfinished > 0 {
+		fmt.Fprintf(writer, ", leaving out %d given up on at the turn limit", unfinished)
+	}
+	fmt.Fprintln(writer, ". Cards that won more often when bought come first.")
+	fmt.Fprintln(writer)
+	header := []string{"card", "offered", "bought", "win rate bought", "win rate not bought", "difference", "average buy turn"}
+	align := []string{"---", "---:", "---:", "---:", "---:", "---:", "---:"}
+	for copies := 0; copies <= REPORT_COPIES; copies++ {
+		header = append(header, "win rate with "+copiesLabel(copies))
+		align = append(align, "---:")
+	}
+	fmt.Fprintf(writer, "| %s |\n", strings.Join(header, " | "))
+	fmt.Fprintf(writer, "| %s |\n", strings.Join(align, " | "))
+	for _, stats := range report {
+		row := []string{
+			stats.Card,
+			strconv.Itoa(stats.Offered),
+			strconv.Itoa(stats.Bought),
+			percent(stats.WinRateBought),
+			percent(stats.WinRateNotBought),
+			fmt.Sprintf("%+.1f%%", 100*stats.Difference()),
+			fmt.Sprintf("%.1f", stats.AverageBuyTurn),
+		}
+		for _, copies := range stats.ByCopies {
+			row = append(row, fmt.Sprintf("%s (%d)", percent(copies.WinRate), copies.Games))
+		}
+		if _, err := fmt.Fprintf(writer, "| %s |\n", strings.Join(row, " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func percent(rate float64) string {
+	return fmt.Sprintf("%.1f%%", 100*rate)
+}
+
+// WriteCardReportCSV writes the report as csv with a row for each card
+func WriteCardReportCSV(writer io.Writer, report []CardStats) error {
+	rows := csv.NewWriter(writer)
+	header := []string{"card", "offered", "bought", "win_rate_bought", "win_rate_not_bought", "win_rate_difference", "average_buy_turn"}
+	for copies := 0; copies <= REPORT_COPIES; copies++ {
+		label := strings.Replace(copiesLabel(copies), "+", "_or_more", 1)
+		header = append(header, "games_"+label+"_copies", "win_rate_"+label+"_copies")
+	}
+	rows.Write(header)
+	for _, stats := range report {
+		row := []string{
+			stats.Card,
+			strconv.Itoa(stats.Offered),
+			strconv.Itoa(stats.Bought),
+			formatFloat(stats.WinRateBought),
+			formatFloat(stats.WinRateNotBought),
+			formatFloat(stats.Difference()),
+			formatFloat(stats.AverageBuyTurn),
+		}
+		for _, copies := range stats.ByCopies {
+			row = append(row, strconv.Itoa(copies.Games), formatFloat(copies.WinRate))
+		}
+		rows.Write(row)
+	}
+	rows.Flush()
+	return rows.Error()
+}
@@ -0,0 +1,71 @@
+package simulate
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func reportResults() []Result {
+	kingdom := []string{"reload", "scavenger"}
+	return []Result{
+		{Finished: true, Kingdom: kingdom, Scores: []int{20, 10}, Winners: []int{0},
+			Buys:     []map[string]int{{"reload": 2, "slug": 1}, {}},
+			BuyTurns: []map[string][]int{{"reload": {3, 7}, "slug": {1}}, {}}},
+		{Finished: true, Kingdom: kingdom, Scores: []int{10, 20}, Winners: []int{1},
+			Buys:     []map[string]int{{"scavenger": 1}, {"reload": 6}},
+			BuyTurns: []map[string][]int{{"scavenger": {2}}, {"reload": {4, 4, 5, 5, 6, 6}}}},
+		{Finished: true, Kingdom: kingdom, Scores: []int{15, 15}, Winners: []int{0, 1},
+			Buys:     []map[string]int{{}, {"reload": 1}},
+			BuyTurns: []map[string][]int{{}, {"reload": {5}}}},
+		{Kingdom: kingdom, Scores: []int{5, 5},
+			Buys:     []map[string]int{{"scavenger": 3}, {}},
+			BuyTurns: []map[string][]int{{"scavenger": {1, 2, 3}}, {}}},
+	}
+}
+
+func TestCardReport(t *testing.T) {
+	report := CardReport(testConfig(t, 0, 0).Definitions, reportResults())
+	if len(report) != 2 || report[0].Card != "reload" || report[1].Card != "scavenger" {
+		t.Fatalf("expected only the kingdom cards, reload helping most, got %+v", report)
+	}
+	if scavenger := report[1]; scavenger.Offered != 6 || scavenger.Bought != 1 {
+		t.Errorf("expected the unfinished game left out of scavenger's stats, got %+v", scavenger)
+	}
+	reload := report[0]
+	if reload.Offered != 6 || reload.Bought != 3 {
+		t.Errorf("expected reload offered 6 times and bought 3, got %d and %d", reload.Offered, reload.Bought)
+	}
+	if reload.WinRateBought != 2.5/3 || reload.WinRateNotBought != 0.5/3 {
+		t.Errorf("expected win rates of 2.5/3 bought and 0.5/3 not, got %v and %v", reload.WinRateBought, reload.WinRateNotBought)
+	}
+	if reload.AverageBuyTurn != 5 {
+		t.Errorf("expected the 9 copies of reload to be bought on turn 5 on average, got %v", reload.AverageBuyTurn)
+	}
+	if copies := reload.ByCopies; copies[1].Games != 1 || copies[1].WinRate != 0.5 || copies[2].WinRate != 1 || copies[REPORT_COPIES].Games != 1 {
+		t.Errorf("unexpected win rates by copies %+v", copies)
+	}
+}
+
+func TestWriteCardReport(t *testing.T) {
+	report := CardReport(testConfig(t, 0, 0).Definitions, reportResults())
+
+	var page bytes.Buffer
+	if err := WriteCardReportMarkdown(&page, []string{"random", "random"}, 4, 1, report); err != nil {
+		t.Fatal(err)
+	}
+	for _, text := range []string{"From 4 games between random, random, leaving out 1 given up on at the turn limit.", "| win rate with 4+ |", "| reload | 6 | 3 | 83.3% | 16.7% | +66.7% | 5.0 |"} {
+		if !strings.Contains(page.String(), text) {
+			t.Errorf("expected %q in\n%s", text, page.String())
+		}
+	}
+
+	var text bytes.Buffer
+	if err := WriteCardReportCSV(&text, report); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(text.String()), "\n")
+	if len(lines) != 3 || !strings.HasSuffix(lines[0], "games_4_or_more_copies,win_rate_4_or_more_copies") || !strings.HasPrefix(lines[1], "reload,6,3,") {
+		t.Errorf("expected a header and a row for each card, got\n%s", text.String())
+	}
+}
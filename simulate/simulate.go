@@ -0,0 +1,173 @@
+// Package simulate plays games between AI strategies without a window and sums up how they went,
+// so the cards can be balanced on numbers from thousands of games.
+package simulate
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/quartermeat/card_game/gamestates"
+	"github.com/quartermeat/card_game/rules"
+	"github.com/quartermeat/card_game/strategy"
+)
+
+// TURN_LIMIT is how many turns each player gets before a game that hasn't ended is given up on
+const TURN_LIMIT = 100
+
+// Config says which games to play
+type Config struct {
+	Definitions rules.CardDefinitions
+	// Seats are the strategy specs of each seat in turn order, like big_money or mcts:200
+	Seats []string
+	// Kingdom chooses the kingdom of every game, random kingdoms are drawn from each game's seed
+	Kingdom rules.KingdomConfig
+	Games   int
+	// Seed is the seed of the first game, every game after it uses the next seed
+	Seed int64
+	// Workers is how many games to play at once, one per CPU if 0
+	Workers int
+}
+
+// Result is how a single game went
+type Result struct {
+	Seed    int64
+	Kingdom []string
+	// Finished is false for a game given up on at the TURN_LIMIT
+	Finished bool
+	// Turns, Scores, Buys and BuyTurns are by seat
+	Turns  []int
+	Scores []int
+	Buys   []map[string]int
+	// BuyTurns are the turns each card was bought on
+	BuyTurns []map[string][]int
+	// Winners are the seats sharing first place, none if the game didn't finish
+	Winners []int
+}
+
+// Length is the most turns any player took
+func (result Result) Length() int {
+	length := 0
+	for _, turns := range result.Turns {
+		if turns > length {
+			length = turns
+		}
+	}
+	return length
+}
+
+// recorder counts the cards a strategy buys and the turns it buys them on
+type recorder struct {
+	strategy.Strategy
+	buys  map[string]int
+	turns map[string][]int
+}
+
+// ChooseBuy passes on the strategy's choice, counting it
+func (recorder *recorder) ChooseBuy(game *rules.GameState, player *rules.Player) *rules.Pile {
+	pile := recorder.Strategy.ChooseBuy(game, player)
+	if pile != nil {
+		recorder.buys[pile.Name]++
+		recorder.turns[pile.Name] = append(recorder.turns[pile.Name], player.Turns)
+	}
+	return pile
+}
+
+// Run plays every game of the config, the results are in the order of their seeds
+// however many workers play them
+func Run(config Config) ([]Result, error) {
+	if len(config.Seats) < rules.MIN_PLAYERS || len(config.Seats) > rules.MAX_PLAYERS {
+		return nil, errors.Errorf("games are for %d to %d seats, not %d", rules.MIN_PLAYERS, rules.MAX_PLAYERS, len(config.Seats))
+	}
+	if config.Games < 1 {
+		return nil, errors.Errorf("a batch needs at least 1 game, not %d", config.Games)
+	}
+	if config.Workers < 0 {
+		return nil, errors.Errorf("workers can't be negative, got %d", config.Workers)
+	}
+	workers := config.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make([]Result, config.Games)
+	errs := make([]error, workers)
+	games := make(chan int)
+	var waitGroup sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		waitGroup.Add(1)
+		go func(worker int) {
+			defer waitGroup.Done()
+			for game := range games {
+				if errs[worker] != nil {
+					continue
+				}
+				strategies := make([]strategy.Strategy, 0, len(config.Seats))
+				for _, spec := range config.Seats {
+					bot, err := strategy.New(spec, config.Definitions)
+					if err != nil {
+						errs[worker] = err
+						break
+					}
+					strategies = append(strategies, bot)
+				}
+				if errs[worker] == nil {
+					results[game], errs[worker] = PlayGame(config.Definitions, config.Kingdom, config.Seed+int64(game), strategies)
+				}
+			}
+		}(worker)
+	}
+	for game := 0; game < config.Games; game++ {
+		games <- game
+	}
+	close(games)
+	waitGroup.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// PlayGame plays a game between strategies from a seed, each strategy playing the seat it is at
+func PlayGame(definitions rules.CardDefinitions, kingdom rules.KingdomConfig, seed int64, strategies []strategy.Strategy) (Result, error) {
+	result := Result{Seed: seed}
+	game := rules.NewGameState(definitions, gamestates.NewStateManager(), seed)
+	var err error
+	if result.Kingdom, err = game.ChooseKingdom(kingdom); err != nil {
+		return result, errors.Wrapf(err, "game %d", seed)
+	}
+	if err := game.SetupSupply(rules.DefaultSetup, len(strategies)); err != nil {
+		return result, errors.Wrapf(err, "game %d", seed)
+	}
+	recorders := make([]*recorder, 0, len(strategies))
+	for seat, bot := range strategies {
+		recorders = append(recorders, &recorder{Strategy: bot, buys: make(map[string]int), turns: make(map[string][]int)})
+		game.AddPlayer(fmt.Sprintf("ai%d", seat+1), false)
+		game.SetDecider(seat, recorders[seat])
+	}
+
+	game.Start()
+	for turn := 0; game.StateManager.IsTurn() && turn < TURN_LIMIT*len(strategies); turn++ {
+		if err := strategy.TakeTurn(game, recorders[game.Current]); err != nil {
+			return result, errors.Wrapf(err, "game %d", seed)
+		}
+	}
+
+	result.Finished = game.StateManager.GetCurrentState() == gamestates.GameOver
+	for seat, player := range game.Players {
+		result.Turns = append(result.Turns, player.Turns)
+		result.Scores = append(result.Scores, game.Score(player))
+		result.Buys = append(result.Buys, recorders[seat].buys)
+		result.BuyTurns = append(result.BuyTurns, recorders[seat].turns)
+	}
+	if result.Finished {
+		for _, winner := range game.Winners() {
+			result.Winners = append(result.Winners, game.PlayerIndex(winner))
+		}
+	}
+	return result, nil
+}
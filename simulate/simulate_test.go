@@ -0,0 +1,95 @@
+package simulate
+
+import (
+	"testing"
+
+	"github.com/quartermeat/card_game/rules"
+)
+
+func testConfig(t *testing.T, games int, workers int) Config {
+	t.Helper()
+	defs, err := rules.LoadCardDefinitions("../assets/cards/cardDefinitions.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Config{
+		Definitions: defs,
+		Seats:       []string{"big_money", "big_money:reload"},
+		Kingdom:     rules.DefaultKingdomConfig(),
+		Games:       games,
+		Seed:        10,
+		Workers:     workers,
+	}
+}
+
+func TestRunPlaysEveryGame(t *testing.T) {
+	results, err := Run(testConfig(t, 20, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 20 {
+		t.Fatalf("expected 20 results, got %d", len(results))
+	}
+	kingdoms := make(map[string]bool)
+	for game, result := range results {
+		if result.Seed != 10+int64(game) {
+			t.Errorf("expected game %d to have seed %d, got %d", game, 10+game, result.Seed)
+		}
+		if !result.Finished || len(result.Winners) == 0 || len(result.Scores) != 2 {
+			t.Errorf("game %d: expected a finished game with a winner, got %+v", game, result)
+		}
+		if contains(result.Kingdom, "reload") && result.Buys[1]["reload"] != 1 {
+			t.Errorf("game %d: expected the second seat to buy one reload, got %v", game, result.Buys[1])
+		}
+		kingdoms[result.Kingdom[0]+result.Kingdom[1]] = true
+	}
+	if len(kingdoms) < 2 {
+		t.Error("expected random kingdoms to differ between games")
+	}
+}
+
+func TestRunIsRepeatable(t *testing.T) {
+	serial, err := Run(testConfig(t, 8, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parallel, err := Run(testConfig(t, 8, 8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for game := range serial {
+		if serial[game].Scores[0] != parallel[game].Scores[0] || serial[game].Length() != parallel[game].Length() {
+			t.Errorf("game %d: expected the same game however many workers play it", game)
+		}
+	}
+}
+
+func TestRunRejectsBadSeats(t *testing.T) {
+	config := testConfig(t, 1, 1)
+	config.Seats = []string{"big_money"}
+	if _, err := Run(config); err == nil {
+		t.Error("expected a single seat to fail")
+	}
+	config.Seats = []string{"big_money", "smart"}
+	if _, err := Run(config); err == nil {
+		t.Error("expected an unknown strategy to fail")
+	}
+}
+
+func TestRunRejectsBadCounts(t *testing.T) {
+	if _, err := Run(testConfig(t, -1, 1)); err == nil {
+		t.Error("expected a negative number of games to fail")
+	}
+	if _, err := Run(testConfig(t, 1, -1)); err == nil {
+		t.Error("expected a negative number of workers to fail")
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, candidate := range names {
+		if candidate == name {
+			return true
+		}
+	}
+	return false
+}
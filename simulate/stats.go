@@ -0,0 +1,150 @@
+package simulate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Stats sums up a batch of games
+type Stats struct {
+	Games int `json:"games"`
+	// Unfinished is how many games were given up on at the TURN_LIMIT
+	Unfinished int `json:"unfinished"`
+	// AverageTurns is the average Length of the finished games
+	AverageTurns float64     `json:"average_turns"`
+	Seats        []SeatStats `json:"seats"`
+}
+
+// SeatStats is how a seat's strategy did across the finished games
+type SeatStats struct {
+	Seat     int    `json:"seat"`
+	Strategy string `json:"strategy"`
+	// Wins counts a shared first place as a fraction of a win
+	Wins    float64      `json:"wins"`
+	WinRate float64      `json:"win_rate"`
+	VP      Distribution `json:"vp"`
+	// Buys is how many of each card the seat bought in an average game
+	Buys map[string]float64 `json:"buys_per_game"`
+}
+
+// Distribution describes a spread of numbers
+type Distribution struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"std_dev"`
+	Min    int     `json:"min"`
+	Median int     `json:"median"`
+	Max    int     `json:"max"`
+}
+
+// Summarize works out the stats of the results of games between the seats. Games given up on
+// at the TURN_LIMIT are only counted as Unfinished, nobody won them.
+func Summarize(seats []string, results []Result) Stats {
+	stats := Stats{Games: len(results)}
+	finished := make([]Result, 0, len(results))
+	for _, result := range results {
+		if !result.Finished {
+			stats.Unfinished++
+			continue
+		}
+		finished = append(finished, result)
+		stats.AverageTurns += float64(result.Length())
+	}
+	if len(finished) > 0 {
+		stats.AverageTurns /= float64(len(finished))
+	}
+
+	for seat, spec := range seats {
+		seatStats := SeatStats{Seat: seat + 1, Strategy: spec, Buys: make(map[string]float64)}
+		scores := make([]int, 0, len(finished))
+		for _, result := range finished {
+			for _, winner := range result.Winners {
+				if winner == seat {
+					seatStats.Wins += 1 / float64(len(result.Winners))
+				}
+			}
+			scores = append(scores, result.Scores[seat])
+			for card, count := range result.Buys[seat] {
+				seatStats.Buys[card] += float64(count)
+			}
+		}
+		if len(finished) > 0 {
+			seatStats.WinRate = seatStats.Wins / float64(len(finished))
+			for card := range seatStats.Buys {
+				seatStats.Buys[card] /= float64(len(finished))
+			}
+		}
+		seatStats.VP = distribution(scores)
+		stats.Seats = append(stats.Seats, seatStats)
+	}
+	return stats
+}
+
+func distribution(values []int) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	spread := Distribution{Min: sorted[0], Median: sorted[len(sorted)/2], Max: sorted[len(sorted)-1]}
+	for _, value := range sorted {
+		spread.Mean += float64(value)
+	}
+	spread.Mean /= float64(len(sorted))
+	for _, value := range sorted {
+		spread.StdDev += (float64(value) - spread.Mean) * (float64(value) - spread.Mean)
+	}
+	spread.StdDev = math.Sqrt(spread.StdDev / float64(len(sorted)))
+	return spread
+}
+
+// WriteJSON writes the stats as an indented JSON object
+func (stats Stats) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(stats)
+}
+
+// WriteCSV writes the stats one number to a row, with the columns metric, seat, strategy, card
+// and value, leaving out the columns that don't apply to the metric
+func (stats Stats) WriteCSV(writer io.Writer) error {
+	rows := csv.NewWriter(writer)
+	rows.Write([]string{"metric", "seat", "strategy", "card", "value"})
+	rows.Write([]string{"games", "", "", "", strconv.Itoa(stats.Games)})
+	rows.Write([]string{"unfinished", "", "", "", strconv.Itoa(stats.Unfinished)})
+	rows.Write([]string{"average_turns", "", "", "", formatFloat(stats.AverageTurns)})
+	for _, seat := range stats.Seats {
+		number := strconv.Itoa(seat.Seat)
+		for _, metric := range []struct {
+			name  string
+			value string
+		}{
+			{"wins", formatFloat(seat.Wins)},
+			{"win_rate", formatFloat(seat.WinRate)},
+			{"vp_mean", formatFloat(seat.VP.Mean)},
+			{"vp_std_dev", formatFloat(seat.VP.StdDev)},
+			{"vp_min", strconv.Itoa(seat.VP.Min)},
+			{"vp_median", strconv.Itoa(seat.VP.Median)},
+			{"vp_max", strconv.Itoa(seat.VP.Max)},
+		} {
+			rows.Write([]string{metric.name, number, seat.Strategy, "", metric.value})
+		}
+		cards := make([]string, 0, len(seat.Buys))
+		for card := range seat.Buys {
+			cards = append(cards, card)
+		}
+		sort.Strings(cards)
+		for _, card := range cards {
+			rows.Write([]string{"buys_per_game", number, seat.Strategy, card, formatFloat(seat.Buys[card])})
+		}
+	}
+	rows.Flush()
+	return rows.Error()
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 4, 64)
+}
@@ -0,0 +1,60 @@
+package simulate
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testResults() []Result {
+	return []Result{
+		{Finished: true, Turns: []int{10, 10}, Scores: []int{20, 10}, Winners: []int{0}, Buys: []map[string]int{{"slug": 2}, {"slug": 1, "reload": 1}}},
+		{Finished: true, Turns: []int{13, 12}, Scores: []int{12, 12}, Winners: []int{1}, Buys: []map[string]int{{"slug": 1}, {}}},
+		{Finished: true, Turns: []int{11, 11}, Scores: []int{15, 15}, Winners: []int{0, 1}, Buys: []map[string]int{{}, {}}},
+		{Finished: false, Turns: []int{100, 100}, Scores: []int{1, 2}, Buys: []map[string]int{{}, {}}},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	stats := Summarize([]string{"big_money", "random"}, testResults())
+	if stats.Games != 4 || stats.Unfinished != 1 || stats.AverageTurns != 34.0/3 {
+		t.Errorf("expected 4 games, 1 unfinished and 34/3 turns, got %+v", stats)
+	}
+	first, second := stats.Seats[0], stats.Seats[1]
+	if first.Wins != 1.5 || second.Wins != 1.5 || first.WinRate != 0.5 {
+		t.Errorf("expected a shared win to count as half, got %v and %v", first.Wins, second.Wins)
+	}
+	if first.VP.Min != 12 || first.VP.Max != 20 || first.VP.Median != 15 || first.VP.Mean != 47.0/3 {
+		t.Errorf("expected the vp of the finished games, got %+v", first.VP)
+	}
+	if first.Buys["slug"] != 1 || second.Buys["reload"] != 1.0/3 {
+		t.Errorf("unexpected buys per game %v and %v", first.Buys, second.Buys)
+	}
+}
+
+func TestWriteStats(t *testing.T) {
+	stats := Summarize([]string{"big_money", "random"}, testResults())
+
+	var text bytes.Buffer
+	if err := stats.WriteCSV(&text); err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range []string{"metric,seat,strategy,card,value", "games,,,,4", "win_rate,2,random,,0.5000", "buys_per_game,1,big_money,slug,1.0000"} {
+		if !strings.Contains(text.String(), row+"\n") {
+			t.Errorf("expected the row %s in\n%s", row, text.String())
+		}
+	}
+
+	text.Reset()
+	if err := stats.WriteJSON(&text); err != nil {
+		t.Fatal(err)
+	}
+	var read Stats
+	if err := json.Unmarshal(text.Bytes(), &read); err != nil {
+		t.Fatal(err)
+	}
+	if read.Games != 4 || read.Seats[1].Strategy != "random" || read.Seats[0].Buys["slug"] != 1 {
+		t.Errorf("expected the json to read back, got %+v", read)
+	}
+}
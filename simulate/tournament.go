@@ -0,0 +1,141 @@
+package simulate
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/quartermeat/card_game/rules"
+	"github.com/quartermeat/card_game/strategy"
+)
+
+// TournamentConfig says which strategies play each other and how often
+type TournamentConfig struct {
+	Definitions rules.CardDefinitions
+	// Entrants are the strategy specs that play every other entrant
+	Entrants []string
+	Kingdom  rules.KingdomConfig
+	// Games is how many seeds each pair plays, every seed is played once with each strategy going first
+	Games   int
+	Seed    int64
+	Workers int
+}
+
+// Match is how two entrants did against each other, counted from the first's side
+type Match struct {
+	First  string
+	Second string
+	Wins   int
+	Draws  int
+	Losses int
+	// Unfinished counts the games given up on at the TURN_LIMIT, they aren't rated
+	Unfinished int
+}
+
+// Entrants returns every registered strategy that can play without an argument
+func Entrants(definitions rules.CardDefinitions) []string {
+	entrants := make([]string, 0)
+	for _, name := range strategy.Names() {
+		if _, err := strategy.New(name, definitions); err == nil {
+			entrants = append(entrants, name)
+		}
+	}
+	return entrants
+}
+
+// Tournament plays a round robin between the entrants, rating every finished game in the Elo table
+func Tournament(config TournamentConfig, table EloTable) ([]Match, error) {
+	if len(config.Entrants) < 2 {
+		return nil, errors.Errorf("a tournament needs at least 2 entrants, not %d", len(config.Entrants))
+	}
+	matches := make([]Match, 0)
+	for first := 0; first < len(config.Entrants); first++ {
+		for second := first + 1; second < len(config.Entrants); second++ {
+			match := Match{First: config.Entrants[first], Second: config.Entrants[second]}
+			forward, err := config.play(match.First, match.Second)
+			if err != nil {
+				return nil, err
+			}
+			backward, err := config.play(match.Second, match.First)
+			if err != nil {
+				return nil, err
+			}
+			for game := range forward {
+				match.record(table, forward[game], 0)
+				match.record(table, backward[game], 1)
+			}
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+// play plays every seed of the tournament with the strategies in that seat order
+func (config TournamentConfig) play(first string, second string) ([]Result, error) {
+	return Run(Config{
+		Definitions: config.Definitions,
+		Seats:       []string{first, second},
+		Kingdom:     config.Kingdom,
+		Games:       config.Games,
+		Seed:        config.Seed,
+		Workers:     config.Workers,
+	})
+}
+
+// record counts a game in the match and the Elo table, seat is where the match's first entrant sat.
+// A game that stalled at the TURN_LIMIT is counted as unfinished rather than rated as a draw.
+func (match *Match) record(table EloTable, result Result, seat int) {
+	if !result.Finished {
+		match.Unfinished++
+		return
+	}
+	score := 0.5
+	if len(result.Winners) == 1 {
+		score = 0
+		if result.Winners[0] == seat {
+			score = 1
+		}
+	}
+	switch score {
+	case 1:
+		match.Wins++
+	case 0:
+		match.Losses++
+	default:
+		match.Draws++
+	}
+	table.Record(match.First, match.Second, score)
+}
+
+// WriteLeaderboard writes the Elo table and the matches of the tournament as a markdown page
+func WriteLeaderboard(writer io.Writer, table EloTable, matches []Match) error {
+	fmt.Fprintln(writer, "# Leaderboard")
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "| rank | strategy | elo | games | wins | draws | losses |")
+	fmt.Fprintln(writer, "| ---: | --- | ---: | ---: | ---: | ---: | ---: |")
+	for rank, rating := range table.Ranked() {
+		fmt.Fprintf(writer, "| %d | %s | %.0f | %d | %d | %d | %d |\n", rank+1, rating.Strategy, rating.Elo, rating.Games, rating.Wins, rating.Draws, rating.Losses)
+	}
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "## Latest tournament")
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "| strategy | opponent | wins | draws | losses | unfinished |")
+	fmt.Fprintln(writer, "| --- | --- | ---: | ---: | ---: | ---: |")
+	for _, match := range matches {
+		if _, err := fmt.Fprintf(writer, "| %s | %s | %d | %d | %d | %d |\n", match.First, match.Second, match.Wins, match.Draws, match.Losses, match.Unfinished); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveLeaderboard writes the leaderboard to the file at path
+func SaveLeaderboard(path string, table EloTable, matches []Match) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "error saving leaderboard")
+	}
+	defer file.Close()
+	return WriteLeaderboard(file, table, matches)
+}
@@ -0,0 +1,70 @@
+package simulate
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/quartermeat/card_game/rules"
+)
+
+func TestEntrants(t *testing.T) {
+	entrants := strings.Join(Entrants(testConfig(t, 0, 0).Definitions), ",")
+	if entrants != "big_money,mcts,random" {
+		t.Errorf("expected the strategies that need no argument, got %s", entrants)
+	}
+}
+
+func TestTournament(t *testing.T) {
+	config := TournamentConfig{
+		Definitions: testConfig(t, 0, 0).Definitions,
+		Entrants:    []string{"random", "big_money", "big_money:reload"},
+		Kingdom:     rules.ParseKingdomConfig("first_night"),
+		Games:       5,
+		Seed:        1,
+	}
+	table := make(EloTable)
+	matches, err := Tournament(config, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 3 {
+		t.Fatalf("expected every pair to play once, got %+v", matches)
+	}
+	for _, match := range matches {
+		if match.Wins+match.Draws+match.Losses+match.Unfinished != 10 {
+			t.Errorf("expected 10 games between %s and %s, got %+v", match.First, match.Second, match)
+		}
+	}
+	if matches[0].First != "random" || matches[0].Wins > matches[0].Losses {
+		t.Errorf("expected big_money to beat random, got %+v", matches[0])
+	}
+	if table["random"].Games != 20 || table["random"].Elo >= table["big_money"].Elo {
+		t.Errorf("expected random to play 20 games and rate below big_money, got %+v", table["random"])
+	}
+
+	var page bytes.Buffer
+	if err := WriteLeaderboard(&page, table, matches); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(page.String(), "| 3 | random |") || !strings.Contains(page.String(), "| random | big_money |") {
+		t.Errorf("expected random last on the leaderboard, got\n%s", page.String())
+	}
+
+	if _, err := Tournament(TournamentConfig{Entrants: []string{"random"}}, table); err == nil {
+		t.Error("expected a tournament of one to fail")
+	}
+}
+
+func TestUnfinishedGamesArentRated(t *testing.T) {
+	table := make(EloTable)
+	match := Match{First: "random", Second: "big_money"}
+	match.record(table, Result{Finished: false, Scores: []int{3, 3}}, 0)
+	if match.Unfinished != 1 || match.Draws != 0 || len(table) != 0 {
+		t.Errorf("expected an unfinished game left out of the ratings, got %+v and %+v", match, table)
+	}
+	match.record(table, Result{Finished: true, Scores: []int{3, 3}, Winners: []int{0, 1}}, 0)
+	if match.Draws != 1 || table["random"] == nil || table["random"].Games != 1 {
+		t.Errorf("expected a shared win to be rated as a draw, got %+v and %+v", match, table["random"])
+	}
+}
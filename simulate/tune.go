@@ -0,0 +1,358 @@
+package simulate
+
+import (
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/quartermeat/card_game/gamestates"
+	"github.com/quartermeat/card_game/rules"
+	"github.com/quartermeat/card_game/strategy"
+)
+
+const (
+	// the best candidates of each generation are kept as they are
+	tune_elites = 2
+	// parents are the best of this many candidates picked at random
+	tune_selection = 3
+	// the chance of a card from the pool being on a new random list
+	tune_keep_card = 0.7
+	// how many mutations mix up a new random list
+	tune_start_mutations = 3
+)
+
+// TuneConfig says how to evolve a priority list
+type TuneConfig struct {
+	Definitions rules.CardDefinitions
+	// Kingdom is the kingdom every game is played on, a random one is drawn once from the seed
+	Kingdom rules.KingdomConfig
+	// Reference is the strategy spec candidates are played against
+	Reference   string
+	Population  int
+	Generations int
+	// Games is how many games each candidate plays in a generation, taking turns to go first
+	Games   int
+	Seed    int64
+	Workers int
+	// Progress is told the best candidate after each generation, it can be nil
+	Progress func(generation int, best Candidate)
+}
+
+// Candidate is a priority list and how it did in its games
+type Candidate struct {
+	List *strategy.PriorityList
+	// Fitness is the share of its games it won
+	Fitness float64
+	// Margin is how many more victory points than the reference it scored in an average game,
+	// it ranks candidates that won as often as each other
+	Margin float64
+}
+
+// beats is true if a candidate ranks above another
+func (candidate Candidate) beats(other Candidate) bool {
+	if candidate.Fitness != other.Fitness {
+		return candidate.Fitness > other.Fitness
+	}
+	return candidate.Margin > other.Margin
+}
+
+// tuner holds what a run of Tune needs between generations
+type tuner struct {
+	config  TuneConfig
+	kingdom []string
+	// pool is every card a list can buy
+	pool []string
+	// actions are the kingdom cards a list can play
+	actions []string
+	rng     *rand.Rand
+}
+
+// Tune evolves priority lists with a genetic algorithm, fitness is the win rate against the
+// reference strategy on the kingdom. Every generation plays new seeds, the same for all candidates.
+// Half of the first generation are Big Money lists, plain and with each kingdom card, so the
+// search starts from lists that already play well; the rest are random.
+func Tune(config TuneConfig) (Candidate, error) {
+	if config.Population < tune_elites+1 || config.Generations < 1 || config.Games < 1 {
+		return Candidate{}, errors.Errorf("tuning needs a population over %d, and generations and games", tune_elites)
+	}
+	tuner := &tuner{config: config, rng: rand.New(rand.NewSource(config.Seed))}
+	game := rules.NewGameState(config.Definitions, gamestates.NewStateManager(), config.Seed)
+	var err error
+	if tuner.kingdom, err = game.ChooseKingdom(config.Kingdom); err != nil {
+		return Candidate{}, err
+	}
+	if _, err = strategy.New(config.Reference, config.Definitions); err != nil {
+		return Candidate{}, err
+	}
+	tuner.pool = []string{"slug", "shells", rules.ZOMBIES, "more_zombies", rules.EVEN_MORE_ZOMBIES}
+	for _, name := range tuner.kingdom {
+		tuner.pool = append(tuner.pool, name)
+		if config.Definitions[name].Is(rules.ACTION) {
+			tuner.actions = append(tuner.actions, name)
+		}
+	}
+
+	population := make([]Candidate, config.Population)
+	seeded := tuner.bigMoneyLists()
+	for index := range population {
+		if index < len(seeded) && index < config.Population/2 {
+			population[index].List = seeded[index]
+			continue
+		}
+		population[index].List = tuner.randomList()
+	}
+	var best Candidate
+	for generation := 0; generation < config.Generations; generation++ {
+		if err := tuner.evaluate(population, config.Seed+int64(generation*config.Games)); err != nil {
+			return Candidate{}, err
+		}
+		sort.SliceStable(population, func(i, j int) bool { return population[i].beats(population[j]) })
+		best = population[0]
+		if config.Progress != nil {
+			config.Progress(generation, best)
+		}
+		if generation < config.Generations-1 {
+			population = tuner.breed(population)
+		}
+	}
+	return best, nil
+}
+
+// evaluate plays every candidate's games against the reference, from seed on
+func (tuner *tuner) evaluate(population []Candidate, seed int64) error {
+	workers := tuner.config.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	games := tuner.config.Games
+	scores := make([]float64, len(population)*games)
+	margins := make([]int, len(population)*games)
+	errs := make([]error, workers)
+	jobs := make(chan int)
+	var waitGroup sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		waitGroup.Add(1)
+		go func(worker int) {
+			defer waitGroup.Done()
+			for job := range jobs {
+				if errs[worker] != nil {
+					continue
+				}
+				// every candidate plays the same seeds from the same seats
+				game := job % games
+				scores[job], margins[job], errs[worker] = tuner.play(population[job/games].List, seed+int64(game), game%2)
+			}
+		}(worker)
+	}
+	for job := range scores {
+		jobs <- job
+	}
+	close(jobs)
+	waitGroup.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	for index := range population {
+		population[index].Fitness, population[index].Margin = 0, 0
+		for game := index * games; game < (index+1)*games; game++ {
+			population[index].Fitness += scores[game]
+			population[index].Margin += float64(margins[game])
+		}
+		population[index].Fitness /= float64(games)
+		population[index].Margin /= float64(games)
+	}
+	return nil
+}
+
+// play plays a game between a candidate at seat and the reference, returning the candidate's
+// share of the win and how many more victory points it scored
+func (tuner *tuner) play(list *strategy.PriorityList, seed int64, seat int) (float64, int, error) {
+	// each game gets its own reference, games are played at the same time
+	reference, err := strategy.New(tuner.config.Reference, tuner.config.Definitions)
+	if err != nil {
+		return 0, 0, err
+	}
+	strategies := []strategy.Strategy{list, reference}
+	if seat == 1 {
+		strategies[0], strategies[1] = strategies[1], strategies[0]
+	}
+	result, err := PlayGame(tuner.config.Definitions, rules.KingdomConfig{Cards: tuner.kingdom}, seed, strategies)
+	if err != nil {
+		return 0, 0, err
+	}
+	margin := result.Scores[seat] - result.Scores[1-seat]
+	for _, winner := range result.Winners {
+		if winner == seat {
+			return 1 / float64(len(result.Winners)), margin, nil
+		}
+	}
+	return 0, margin, nil
+}
+
+// breed makes the next generation from a population sorted best first: the elites carry on and
+// the rest are mutated children of parents chosen by tournament selection
+func (tuner *tuner) breed(population []Candidate) []Candidate {
+	next := make([]Candidate, 0, len(population))
+	next = append(next, population[:tune_elites]...)
+	for len(next) < len(population) {
+		child := tuner.crossover(tuner.pickParent(population).List, tuner.pickParent(population).List)
+		tuner.mutate(child)
+		next = append(next, Candidate{List: child})
+	}
+	return next
+}
+
+// pickParent returns the best of a few candidates picked at random
+func (tuner *tuner) pickParent(population []Candidate) Candidate {
+	best := population[tuner.rng.Intn(len(population))]
+	for pick := 1; pick < tune_selection; pick++ {
+		if candidate := population[tuner.rng.Intn(len(population))]; candidate.beats(best) {
+			best = candidate
+		}
+	}
+	return best
+}
+
+// randomList makes a list that buys a random selection of the pool, starting from the most
+// expensive cards first and then mixed up by a few mutations
+func (tuner *tuner) randomList() *strategy.PriorityList {
+	list := &strategy.PriorityList{Plays: append([]string(nil), tuner.actions...)}
+	tuner.rng.Shuffle(len(list.Plays), func(i, j int) { list.Plays[i], list.Plays[j] = list.Plays[j], list.Plays[i] })
+	for _, index := range tuner.rng.Perm(len(tuner.pool)) {
+		if tuner.rng.Float64() < tune_keep_card {
+			list.Buys = append(list.Buys, tuner.randomRule(tuner.pool[index]))
+		}
+	}
+	sort.SliceStable(list.Buys, func(i, j int) bool {
+		return tuner.config.Definitions[list.Buys[i].Card].Cost > tuner.config.Definitions[list.Buys[j].Card].Cost
+	})
+	for mutation := 0; mutation < tune_start_mutations; mutation++ {
+		tuner.mutate(list)
+	}
+	return list
+}
+
+// bigMoneyLists are Big Money priority lists: buying the best treasure or victory card it can,
+// and then one list for each kingdom card that also buys and plays up to 2 of it
+func (tuner *tuner) bigMoneyLists() []*strategy.PriorityList {
+	lists := make([]*strategy.PriorityList, 0, len(tuner.kingdom)+1)
+	for _, card := range append([]string{""}, tuner.kingdom...) {
+		list := &strategy.PriorityList{Plays: append([]string(nil), tuner.actions...)}
+		list.Buys = []strategy.BuyRule{
+			{Card: rules.EVEN_MORE_ZOMBIES},
+			{Card: "more_zombies", Endgame: 4},
+			{Card: "shells"},
+		}
+		if card != "" {
+			if at := indexOf(list.Plays, card); at >= 0 {
+				list.Plays = append(append([]string{card}, list.Plays[:at]...), list.Plays[at+1:]...)
+			}
+			list.Buys = append(list.Buys, strategy.BuyRule{Card: card, Max: 2})
+		}
+		list.Buys = append(list.Buys,
+			strategy.BuyRule{Card: "more_zombies", Endgame: 2},
+			strategy.BuyRule{Card: "slug"},
+			strategy.BuyRule{Card: rules.ZOMBIES, Endgame: 2},
+		)
+		lists = append(lists, list)
+	}
+	return lists
+}
+
+func indexOf(names []string, name string) int {
+	for index, candidate := range names {
+		if candidate == name {
+			return index
+		}
+	}
+	return -1
+}
+
+// randomRule buys a card with some of its conditions set at random
+func (tuner *tuner) randomRule(card string) strategy.BuyRule {
+	rule := strategy.BuyRule{Card: card}
+	if tuner.rng.Intn(2) == 0 {
+		rule.Max = 1 + tuner.rng.Intn(4)
+	}
+	if tuner.rng.Intn(4) == 0 {
+		rule.Coins = 2 + tuner.rng.Intn(7)
+	}
+	if tuner.rng.Intn(4) == 0 {
+		rule.Endgame = 1 + tuner.rng.Intn(6)
+	}
+	return rule
+}
+
+// crossover takes the start of one parent's buy list and carries on with the rules of the other
+// for cards it doesn't buy yet, the play order comes from either parent
+func (tuner *tuner) crossover(first *strategy.PriorityList, second *strategy.PriorityList) *strategy.PriorityList {
+	child := &strategy.PriorityList{Plays: append([]string(nil), first.Plays...)}
+	if tuner.rng.Intn(2) == 0 {
+		child.Plays = append([]string(nil), second.Plays...)
+	}
+	cut := tuner.rng.Intn(len(first.Buys) + 1)
+	child.Buys = append([]strategy.BuyRule(nil), first.Buys[:cut]...)
+	for _, rule := range second.Buys {
+		if buysCard(child, rule.Card) < 0 {
+			child.Buys = append(child.Buys, rule)
+		}
+	}
+	return child
+}
+
+// mutate makes one random change to a list: moving, adding, dropping or retuning a buy,
+// or swapping two plays
+func (tuner *tuner) mutate(list *strategy.PriorityList) {
+	buys := len(list.Buys)
+	switch tuner.rng.Intn(5) {
+	case 0:
+		if buys > 1 {
+			i := tuner.rng.Intn(buys - 1)
+			list.Buys[i], list.Buys[i+1] = list.Buys[i+1], list.Buys[i]
+		}
+	case 1:
+		card := tuner.pool[tuner.rng.Intn(len(tuner.pool))]
+		if buysCard(list, card) < 0 {
+			at := tuner.rng.Intn(buys + 1)
+			list.Buys = append(list.Buys[:at], append([]strategy.BuyRule{tuner.randomRule(card)}, list.Buys[at:]...)...)
+		}
+	case 2:
+		if buys > 1 {
+			at := tuner.rng.Intn(buys)
+			list.Buys = append(list.Buys[:at], list.Buys[at+1:]...)
+		}
+	case 3:
+		if buys > 0 {
+			rule := &list.Buys[tuner.rng.Intn(buys)]
+			fresh := tuner.randomRule(rule.Card)
+			switch tuner.rng.Intn(3) {
+			case 0:
+				rule.Max = fresh.Max
+			case 1:
+				rule.Coins = fresh.Coins
+			case 2:
+				rule.Endgame = fresh.Endgame
+			}
+		}
+	case 4:
+		if plays := len(list.Plays); plays > 1 {
+			i, j := tuner.rng.Intn(plays), tuner.rng.Intn(plays)
+			list.Plays[i], list.Plays[j] = list.Plays[j], list.Plays[i]
+		}
+	}
+}
+
+// buysCard returns where a list buys a card, -1 if it doesn't
+func buysCard(list *strategy.PriorityList, card string) int {
+	for index, rule := range list.Buys {
+		if rule.Card == card {
+			return index
+		}
+	}
+	return -1
+}
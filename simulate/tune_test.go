@@ -0,0 +1,111 @@
+package simulate
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/quartermeat/card_game/rules"
+	"github.com/quartermeat/card_game/strategy"
+)
+
+func testTuneConfig(t *testing.T) TuneConfig {
+	t.Helper()
+	return TuneConfig{
+		Definitions: testConfig(t, 0, 0).Definitions,
+		Kingdom:     rules.ParseKingdomConfig("first_night"),
+		Reference:   "big_money",
+		Population:  6,
+		Generations: 3,
+		Games:       6,
+		Seed:        4,
+	}
+}
+
+func TestTune(t *testing.T) {
+	config := testTuneConfig(t)
+	generations := 0
+	config.Progress = func(generation int, best Candidate) {
+		generations++
+	}
+	best, err := Tune(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if generations != 3 {
+		t.Errorf("expected progress after each of 3 generations, got %d", generations)
+	}
+	if best.Fitness < 0 || best.Fitness > 1 {
+		t.Errorf("expected a win rate, got %v", best.Fitness)
+	}
+	if err := best.List.Check(config.Definitions); err != nil {
+		t.Error(err)
+	}
+
+	again, err := Tune(testTuneConfig(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Fitness != best.Fitness || len(again.List.Buys) != len(best.List.Buys) {
+		t.Error("expected the same seed to tune the same list")
+	}
+}
+
+func TestTuneRejectsBadConfig(t *testing.T) {
+	config := testTuneConfig(t)
+	config.Population = 1
+	if _, err := Tune(config); err == nil {
+		t.Error("expected too small a population to fail")
+	}
+	config = testTuneConfig(t)
+	config.Reference = "smart"
+	if _, err := Tune(config); err == nil {
+		t.Error("expected an unknown reference strategy to fail")
+	}
+}
+
+func TestBreedingKeepsListsValid(t *testing.T) {
+	defs := testConfig(t, 0, 0).Definitions
+	tuner := &tuner{
+		config:  TuneConfig{Definitions: defs},
+		pool:    append([]string{"slug", "shells", rules.ZOMBIES, "more_zombies", rules.EVEN_MORE_ZOMBIES}, rules.KingdomPresets["first_night"]...),
+		actions: rules.KingdomPresets["first_night"],
+		rng:     rand.New(rand.NewSource(1)),
+	}
+	lists := []*strategy.PriorityList{tuner.randomList(), tuner.randomList()}
+	for round := 0; round < 500; round++ {
+		child := tuner.crossover(lists[0], lists[1])
+		tuner.mutate(child)
+		if err := child.Check(defs); err != nil {
+			t.Fatal(err)
+		}
+		seen := make(map[string]bool)
+		for _, rule := range child.Buys {
+			if seen[rule.Card] {
+				t.Fatalf("expected each card to be bought by one rule, got %+v", child.Buys)
+			}
+			seen[rule.Card] = true
+		}
+		if len(child.Plays) != len(tuner.actions) {
+			t.Fatalf("expected every action to stay on the play list, got %v", child.Plays)
+		}
+		lists[round%2] = child
+	}
+}
+
+func TestBigMoneyListsStartTheSearch(t *testing.T) {
+	defs := testConfig(t, 0, 0).Definitions
+	kingdom := rules.KingdomPresets["first_night"]
+	tuner := &tuner{config: TuneConfig{Definitions: defs}, kingdom: kingdom, actions: kingdom}
+	lists := tuner.bigMoneyLists()
+	if len(lists) != len(kingdom)+1 {
+		t.Fatalf("expected a plain list and one for each kingdom card, got %d", len(lists))
+	}
+	for index, list := range lists {
+		if err := list.Check(defs); err != nil {
+			t.Fatal(err)
+		}
+		if index > 0 && (list.Plays[0] != kingdom[index-1] || buysCard(list, kingdom[index-1]) < 0) {
+			t.Errorf("expected the list to buy and play %s first, got %+v", kingdom[index-1], list)
+		}
+	}
+}
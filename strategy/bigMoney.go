@@ -0,0 +1,49 @@
+// Package strategy has the bots that play the AI seats. A bot takes whole turns through the
+// rules engine and answers its own decisions, so it plays by the same rules as a person.
+package strategy
+
+import (
+	"github.com/quartermeat/card_game/rules"
+)
+
+// BigMoney plays every treasure and buys the best treasure or victory card it can afford:
+// even_more_zombies at 8, shells at 6 and slug at 3. It can also be given a kingdom card
+// to buy and play, like reload, which it buys instead of treasure with 4 or 5 coins.
+type BigMoney struct {
+	// Kingdom is the optional kingdom card to buy and play
+	Kingdom string
+	// KingdomCopies is the most copies of the kingdom card to own
+	KingdomCopies int
+}
+
+// NewBigMoney creates a Big Money bot, kingdom is the optional kingdom card to buy up to copies of
+func NewBigMoney(kingdom string, copies int) *BigMoney {
+	return &BigMoney{Kingdom: kingdom, KingdomCopies: copies}
+}
+
+// ChooseAction plays the kingdom card whenever it is in hand
+func (bot *BigMoney) ChooseAction(game *rules.GameState, player *rules.Player) *rules.Card {
+	return findCard(player.Hand, bot.Kingdom)
+}
+
+// ChooseBuy returns the supply pile to buy from with the coins left, nil to buy nothing
+func (bot *BigMoney) ChooseBuy(game *rules.GameState, player *rules.Player) *rules.Pile {
+	coins := game.StateManager.GetCoins()
+	if bot.Kingdom != "" && coins < 6 && countCards(player, bot.Kingdom) < bot.KingdomCopies {
+		if pile := affordable(game, bot.Kingdom, coins); pile != nil {
+			return pile
+		}
+	}
+	for _, name := range []string{rules.EVEN_MORE_ZOMBIES, "shells", "slug"} {
+		if pile := affordable(game, name, coins); pile != nil {
+			return pile
+		}
+	}
+	return nil
+}
+
+// Decide answers decisions: discarding and trashing the worst cards, gaining the most expensive,
+// and always revealing reactions
+func (bot *BigMoney) Decide(game *rules.GameState, decision *rules.Decision) []int {
+	return decideByValue(game, decision)
+}
@@ -0,0 +1,117 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/quartermeat/card_game/rules"
+)
+
+func TestBigMoneyBuysByCoins(t *testing.T) {
+	for _, test := range []struct {
+		hand []string
+		buy  string
+	}{
+		{[]string{"shells", "shells", "slug"}, rules.EVEN_MORE_ZOMBIES},
+		{[]string{"shells", "bullet", "bullet", "bullet", rules.ZOMBIES}, "shells"},
+		{[]string{"slug", "bullet", rules.ZOMBIES}, "slug"},
+		{[]string{"bullet", "bullet", rules.ZOMBIES}, ""},
+	} {
+		bot := NewBigMoney("", 0)
+		game := newBotGame(t, 1, bot, bot)
+		setHand(game, test.hand...)
+		before := supplyCounts(game)
+
+		if err := TakeTurn(game, bot); err != nil {
+			t.Fatal(err)
+		}
+		if game.Current != 1 {
+			t.Errorf("%v: expected the turn to pass to the next seat", test.hand)
+		}
+		got := bought(game, before)
+		if test.buy == "" {
+			if len(got) != 0 {
+				t.Errorf("%v: expected nothing to be bought, bought %v", test.hand, got)
+			}
+		} else if len(got) != 1 || got[0] != test.buy {
+			t.Errorf("%v: expected to buy %s, bought %v", test.hand, test.buy, got)
+		}
+	}
+}
+
+func TestBigMoneyKingdomCard(t *testing.T) {
+	bot := NewBigMoney("reload", 1)
+	game := newBotGame(t, 1, bot, bot)
+	player := game.CurrentPlayer()
+	setHand(game, "slug", "slug")
+	before := supplyCounts(game)
+
+	if err := TakeTurn(game, bot); err != nil {
+		t.Fatal(err)
+	}
+	if got := bought(game, before); len(got) != 1 || got[0] != "reload" {
+		t.Fatalf("expected reload to be bought with 4 coins, bought %v", got)
+	}
+
+	// with its one copy owned it goes back to buying treasure
+	setHand(game, "bullet")
+	if err := TakeTurn(game, bot); err != nil {
+		t.Fatal(err)
+	}
+	setHand(game, "slug", "slug")
+	before = supplyCounts(game)
+	if err := TakeTurn(game, bot); err != nil {
+		t.Fatal(err)
+	}
+	if got := bought(game, before); len(got) != 1 || got[0] != "slug" {
+		t.Errorf("expected slug once reload is owned, bought %v", got)
+	}
+	if countCards(player, "reload") != 1 {
+		t.Errorf("expected one reload, got %d", countCards(player, "reload"))
+	}
+}
+
+func TestBigMoneyPlaysKingdomCard(t *testing.T) {
+	bot := NewBigMoney("reload", 1)
+	game := newBotGame(t, 1, bot, bot)
+	player := game.CurrentPlayer()
+	setHand(game, "reload")
+	deck := player.Deck.Len()
+
+	if err := TakeTurn(game, bot); err != nil {
+		t.Fatal(err)
+	}
+	if player.Deck.Len() >= deck-3 {
+		t.Errorf("expected reload to draw 3 cards, deck went from %d to %d", deck, player.Deck.Len())
+	}
+}
+
+func TestBigMoneyDecide(t *testing.T) {
+	bot := NewBigMoney("", 0)
+	game := newBotGame(t, 1, bot, bot)
+
+	discard := &rules.Decision{
+		Kind:    rules.CHOOSE_CARDS,
+		Options: []rules.Option{{Name: "shells"}, {Name: rules.ZOMBIES}, {Name: "bullet"}, {Name: rules.INFECTION}},
+		Min:     2,
+		Max:     2,
+	}
+	picks := bot.Decide(game, discard)
+	if len(picks) != 2 || discard.Options[picks[0]].Name != rules.INFECTION || discard.Options[picks[1]].Name != rules.ZOMBIES {
+		t.Errorf("expected the infection and zombies to be discarded, got %v", picks)
+	}
+
+	gain := &rules.Decision{
+		Kind:    rules.CHOOSE_PILE,
+		Options: []rules.Option{{Name: "bullet"}, {Name: "reload"}, {Name: "slug"}},
+		Min:     1,
+		Max:     1,
+	}
+	if picks := bot.Decide(game, gain); len(picks) != 1 || gain.Options[picks[0]].Name != "reload" {
+		t.Errorf("expected reload to be gained, got %v", picks)
+	}
+
+	reveal := &rules.Decision{Kind: rules.YES_NO, Options: []rules.Option{{Name: "yes"}, {Name: "no"}}, Min: 1, Max: 1}
+	if picks := bot.Decide(game, reveal); len(picks) != 1 || picks[0] != rules.YES {
+		t.Errorf("expected reactions to be revealed, got %v", picks)
+	}
+}
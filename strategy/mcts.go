@@ -0,0 +1,283 @@
+package strategy
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/quartermeat/card_game/gamestates"
+	"github.com/quartermeat/card_game/rules"
+)
+
+const (
+	// MCTS_ITERATIONS is how many playouts the mcts strategy runs for each choice when not told otherwise
+	MCTS_ITERATIONS = 300
+	// how far the UCB formula leans towards trying moves that haven't been played out much
+	mcts_exploration = 0.7
+	// how far selection leans towards the move the playout strategy would make, fading as it is played out
+	mcts_bias = 2.0
+	// playouts stop after this many turns and are scored as they stand
+	mcts_playout_turns = 100
+)
+
+// MCTS chooses by Monte Carlo tree search. The cards it can't see, the order of its own deck and
+// the hands and decks of its opponents, are dealt out again at random for every playout, and the
+// tree of moves is shared between those deals. Playouts are finished by the Playout strategy,
+// and the search starts from its moves, so it only strays from them when the playouts say so.
+type MCTS struct {
+	// Iterations is the most playouts to run for each choice, no limit if 0
+	Iterations int
+	// Budget is the most time to spend on each choice, no limit if 0
+	Budget time.Duration
+	// Playout finishes the game from the leaves of the tree, Big Money if nil
+	Playout Strategy
+}
+
+// NewMCTS creates a tree search bot limited to iterations playouts and budget of time for each
+// choice, whichever runs out first. With neither set it runs MCTS_ITERATIONS playouts.
+func NewMCTS(iterations int, budget time.Duration) *MCTS {
+	if iterations <= 0 && budget <= 0 {
+		iterations = MCTS_ITERATIONS
+	}
+	return &MCTS{Iterations: iterations, Budget: budget}
+}
+
+// node is a move in the search tree, its statistics are from the view of the seat that made it
+type node struct {
+	move     rules.Move
+	seat     int
+	parent   *node
+	children []*node
+	visits   int
+	// available is how many times the move was legal when its parent was visited
+	available int
+	reward    float64
+}
+
+// ChooseAction searches for the best move and plays it if it is an action
+func (bot *MCTS) ChooseAction(game *rules.GameState, player *rules.Player) *rules.Card {
+	move := bot.search(game)
+	if move.Kind != rules.PLAY {
+		return nil
+	}
+	return findCard(player.Hand, move.Card)
+}
+
+// ChooseBuy searches for the best move and makes it if it is a buy
+func (bot *MCTS) ChooseBuy(game *rules.GameState, player *rules.Player) *rules.Pile {
+	move := bot.search(game)
+	if move.Kind != rules.BUY {
+		return nil
+	}
+	return game.SupplyPile(move.Card)
+}
+
+// Decide searches for the best answer to the decision
+func (bot *MCTS) Decide(game *rules.GameState, decision *rules.Decision) []int {
+	move := bot.search(game)
+	picks, err := decision.Indexes(move.Picks)
+	if err != nil {
+		return decideByValue(game, decision)
+	}
+	return picks
+}
+
+// search returns the move for the chooser with the most playouts once the budget runs out
+func (bot *MCTS) search(game *rules.GameState) rules.Move {
+	seat := game.Chooser()
+	moves := candidates(game)
+	if len(moves) == 1 {
+		return moves[0]
+	}
+	rng := rand.New(rand.NewSource(game.Rand.Int63()))
+	root := &node{seat: -1}
+	start := time.Now()
+	for iteration := 0; bot.Iterations <= 0 || iteration < bot.Iterations; iteration++ {
+		if bot.Budget > 0 && iteration > 0 && time.Since(start) > bot.Budget {
+			break
+		}
+		clone := game.Clone(rng.Int63())
+		determinize(clone, seat, rng)
+		bot.iterate(root, clone, rng)
+	}
+
+	var best *node
+	for _, child := range root.children {
+		if best == nil || child.visits > best.visits {
+			best = child
+		}
+	}
+	if best == nil {
+		return moves[len(moves)-1]
+	}
+	return best.move
+}
+
+// iterate runs one playout: it selects moves down the tree, adds a move it hasn't tried, plays
+// the game out and passes the result back up the tree
+func (bot *MCTS) iterate(root *node, game *rules.GameState, rng *rand.Rand) {
+	current := root
+	for {
+		seat := game.Chooser()
+		if seat < 0 {
+			break
+		}
+		suggested := bot.suggest(game).String()
+		untried := make([]rules.Move, 0)
+		var chosen *node
+		best := 0.0
+		for _, move := range candidates(game) {
+			child := current.child(move)
+			if child == nil {
+				untried = append(untried, move)
+				continue
+			}
+			child.available++
+			score := child.ucb()
+			if move.String() == suggested {
+				score += mcts_bias / math.Sqrt(float64(child.visits+1))
+			}
+			if chosen == nil || score > best {
+				chosen, best = child, score
+			}
+		}
+		if len(untried) > 0 {
+			move := untried[rng.Intn(len(untried))]
+			for _, candidate := range untried {
+				if candidate.String() == suggested {
+					move = candidate
+				}
+			}
+			if game.Apply(move) != nil {
+				return
+			}
+			chosen = &node{move: move, seat: seat, parent: current, available: 1}
+			current.children = append(current.children, chosen)
+			current = chosen
+			break
+		}
+		if game.Apply(chosen.move) != nil {
+			break
+		}
+		current = chosen
+	}
+
+	rewards := bot.playout(game)
+	for ; current != nil; current = current.parent {
+		current.visits++
+		if current.seat >= 0 {
+			current.reward += rewards[current.seat]
+		}
+	}
+}
+
+// playoutStrategy returns the strategy that finishes the game from the leaves of the tree
+func (bot *MCTS) playoutStrategy() Strategy {
+	if bot.Playout == nil {
+		return NewBigMoney("", 0)
+	}
+	return bot.Playout
+}
+
+// suggest returns the move the playout strategy would make next
+func (bot *MCTS) suggest(game *rules.GameState) rules.Move {
+	playout := bot.playoutStrategy()
+	if decision := game.PendingDecision(); decision != nil {
+		picks := append([]int(nil), playout.Decide(game, decision)...)
+		sort.Ints(picks)
+		names := make([]string, 0, len(picks))
+		for _, pick := range picks {
+			if pick >= 0 && pick < len(decision.Options) {
+				names = append(names, decision.Options[pick].Name)
+			}
+		}
+		return rules.Move{Kind: rules.ANSWER, Picks: names}
+	}
+	player := game.CurrentPlayer()
+	if game.StateManager.GetPhase() == gamestates.ActionPhase && game.StateManager.GetActions() > 0 {
+		if card := playout.ChooseAction(game, player); card != nil {
+			return rules.Move{Kind: rules.PLAY, Card: card.Name}
+		}
+	}
+	// strategies choose their buy with their treasures played, so that is tried on a copy
+	probe := game.Clone(0)
+	if probe.PlayAllTreasures(probe.CurrentPlayer()) == nil {
+		if pile := playout.ChooseBuy(probe, probe.CurrentPlayer()); pile != nil {
+			return rules.Move{Kind: rules.BUY, Card: pile.Name}
+		}
+	}
+	return rules.Move{Kind: rules.END_TURN}
+}
+
+// candidates are the legal moves worth searching, buying a curse never is
+func candidates(game *rules.GameState) []rules.Move {
+	moves := make([]rules.Move, 0)
+	for _, move := range game.LegalMoves() {
+		if move.Kind == rules.BUY {
+			if def := game.Definitions[move.Card]; def != nil && def.Is(rules.CURSE) {
+				continue
+			}
+		}
+		moves = append(moves, move)
+	}
+	return moves
+}
+
+// playout finishes the game with the playout strategy and shares a reward of 1 between the winners
+func (bot *MCTS) playout(game *rules.GameState) []float64 {
+	playout := bot.playoutStrategy()
+	for seat := range game.Players {
+		game.SetDecider(seat, playout)
+	}
+	if decision := game.PendingDecision(); decision != nil {
+		if game.Answer(playout.Decide(game, decision)) != nil {
+			game.Answer(rules.FirstOptions(game, decision))
+		}
+	}
+	for turn := 0; game.StateManager.IsTurn() && turn < mcts_playout_turns; turn++ {
+		if TakeTurn(game, playout) != nil {
+			break
+		}
+	}
+
+	rewards := make([]float64, len(game.Players))
+	winners := game.Winners()
+	for _, winner := range winners {
+		rewards[game.PlayerIndex(winner)] = 1 / float64(len(winners))
+	}
+	return rewards
+}
+
+// child returns the child of a node for a move, nil if it hasn't been tried
+func (parent *node) child(move rules.Move) *node {
+	description := move.String()
+	for _, child := range parent.children {
+		if child.move.String() == description {
+			return child
+		}
+	}
+	return nil
+}
+
+// ucb scores a child for selection, the average reward plus a bonus for moves played out less often
+func (child *node) ucb() float64 {
+	return child.reward/float64(child.visits) + mcts_exploration*math.Sqrt(math.Log(float64(child.available))/float64(child.visits))
+}
+
+// determinize deals out again what seat can't see: the order of its own deck, and the hands and
+// decks of the other players, which are shuffled together and dealt back out at the same sizes
+func determinize(game *rules.GameState, seat int, rng *rand.Rand) {
+	for index, player := range game.Players {
+		if index == seat {
+			player.Deck.Shuffle(rng)
+			continue
+		}
+		hand := player.Hand.Len()
+		unseen := make([]*rules.Card, 0, hand+player.Deck.Len())
+		unseen = append(append(unseen, player.Hand.Cards...), player.Deck.Cards...)
+		rng.Shuffle(len(unseen), func(i, j int) { unseen[i], unseen[j] = unseen[j], unseen[i] })
+		player.Hand.Cards = unseen[:hand:hand]
+		player.Deck.Cards = unseen[hand:]
+	}
+}
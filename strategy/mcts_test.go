@@ -0,0 +1,138 @@
+package strategy
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/quartermeat/card_game/rules"
+)
+
+func cardNames(cards ...[]*rules.Card) []string {
+	names := make([]string, 0)
+	for _, pile := range cards {
+		for _, card := range pile {
+			names = append(names, card.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sameNames(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for index := range a {
+		if a[index] != b[index] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDeterminizeHidesOnlyWhatIsUnseen(t *testing.T) {
+	bot := NewBigMoney("", 0)
+	game := newBotGame(t, 5, bot, bot, bot)
+	game.Players[1].Hand.Push(game.NewCard("reload"))
+	before := cardNames(game.Players[1].Hand.Cards)
+	clone := game.Clone(1)
+	determinize(clone, 0, rand.New(rand.NewSource(1)))
+
+	if !sameNames(cardNames(game.Players[0].Hand.Cards), cardNames(clone.Players[0].Hand.Cards)) {
+		t.Error("expected the searching seat's hand to be kept")
+	}
+	for seat, player := range game.Players {
+		copied := clone.Players[seat]
+		if copied.Hand.Len() != player.Hand.Len() || copied.Deck.Len() != player.Deck.Len() {
+			t.Errorf("seat %d: expected the hand and deck sizes to be kept", seat)
+		}
+		if !sameNames(cardNames(player.Hand.Cards, player.Deck.Cards), cardNames(copied.Hand.Cards, copied.Deck.Cards)) {
+			t.Errorf("seat %d: expected the same cards between hand and deck", seat)
+		}
+	}
+	if !sameNames(cardNames(game.Players[1].Hand.Cards), before) {
+		t.Error("expected the original to be left alone")
+	}
+}
+
+func TestMCTSTakesTheWinningBuy(t *testing.T) {
+	bot := NewMCTS(200, 0)
+	game := newBotGame(t, 1, bot, NewBigMoney("", 0))
+	setHand(game, "shells", "shells", "slug")
+	endgame := game.SupplyPile(rules.EVEN_MORE_ZOMBIES)
+	endgame.Cards = endgame.Cards[:1]
+	// the opponent is ahead and can afford the last one on their turn, whatever they draw
+	opponent := game.Players[1]
+	opponent.Discard.Push(game.NewCard("more_zombies"))
+	opponent.Hand.Cards, opponent.Deck.Cards = nil, nil
+	for count := 0; count < rules.HAND_SIZE; count++ {
+		opponent.Hand.Push(game.NewCard("shells"))
+		opponent.Deck.Push(game.NewCard("shells"))
+	}
+
+	if pile := bot.ChooseBuy(game, game.CurrentPlayer()); pile != endgame {
+		t.Errorf("expected to buy the last even_more_zombies and win, got %v", pile)
+	}
+}
+
+func TestMCTSDecisionsAreValid(t *testing.T) {
+	bot := NewMCTS(30, 0)
+	game := newBotGame(t, 1, bot, bot)
+	player := game.CurrentPlayer()
+	setHand(game, "cunning", rules.INFECTION, "bullet", rules.ZOMBIES)
+	game.SetDecider(0, nil)
+
+	if err := game.PlayAction(player, player.Hand.Cards[0]); err != nil {
+		t.Fatal(err)
+	}
+	decision := game.PendingDecision()
+	if decision == nil {
+		t.Fatal("expected cunning to ask what to trash")
+	}
+	if err := decision.Check(bot.Decide(game, decision)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMCTSBudget(t *testing.T) {
+	bot := NewMCTS(0, 20*time.Millisecond)
+	game := newBotGame(t, 1, bot, bot)
+
+	start := time.Now()
+	bot.ChooseBuy(game, game.CurrentPlayer())
+	if time.Since(start) > time.Second {
+		t.Errorf("expected the search to stop after its budget, took %v", time.Since(start))
+	}
+}
+
+func TestMCTSMatchesBigMoney(t *testing.T) {
+	if testing.Short() {
+		t.Skip("plays whole games with the default search")
+	}
+	// each seed is played with both seat orders, a shared win counts half to each side
+	mcts, bigMoney := 0.0, 0.0
+	for seed := int64(1); seed <= 5; seed++ {
+		for first := 0; first < 2; first++ {
+			seats := []Strategy{NewMCTS(0, 0), NewBigMoney("", 0)}
+			if first == 1 {
+				seats[0], seats[1] = seats[1], seats[0]
+			}
+			game := newBotGame(t, seed, seats[0], seats[1])
+			playGame(t, game, seats...)
+			winners := game.Winners()
+			for _, winner := range winners {
+				if _, ok := seats[game.PlayerIndex(winner)].(*MCTS); ok {
+					mcts += 1 / float64(len(winners))
+				} else {
+					bigMoney += 1 / float64(len(winners))
+				}
+			}
+		}
+	}
+	t.Logf("mcts won %v games, big_money %v", mcts, bigMoney)
+	if mcts < bigMoney {
+		t.Errorf("expected mcts to win at least as often as big_money, won %v to %v", mcts, bigMoney)
+	}
+}
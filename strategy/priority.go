@@ -0,0 +1,202 @@
+package strategy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/quartermeat/card_game/rules"
+)
+
+// BuyRule is a line of a priority list: a card to buy and when to buy it
+type BuyRule struct {
+	Card string
+	// Max is how many copies to own at most, no limit if 0
+	Max int
+	// Coins is the fewest coins to buy the card with, on top of being able to afford it
+	Coins int
+	// Endgame only buys the card once the even_more_zombies pile is down to this many cards, any time if 0
+	Endgame int
+}
+
+// PriorityList is a bot scripted by a file like assets/bots/big_money_reload.txt. It plays the
+// actions it lists in order and buys the first card down its list it can.
+type PriorityList struct {
+	Plays []string
+	Buys  []BuyRule
+}
+
+// LoadPriorityList reads the priority list file at path
+func LoadPriorityList(path string) (*PriorityList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading priority list")
+	}
+	defer file.Close()
+
+	list, err := ReadPriorityList(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading priority list from %s", path)
+	}
+	return list, nil
+}
+
+// ReadPriorityList reads a priority list in the format of assets/bots/big_money_reload.txt
+func ReadPriorityList(reader io.Reader) (*PriorityList, error) {
+	list := &PriorityList{}
+	lines := bufio.NewScanner(reader)
+	for number := 1; lines.Scan(); number++ {
+		line, _, _ := strings.Cut(lines.Text(), "#")
+		words := strings.Fields(line)
+		if len(words) == 0 {
+			continue
+		}
+		if len(words) < 2 {
+			return nil, errors.Errorf("line %d: %q needs a card", number, words[0])
+		}
+		switch words[0] {
+		case "play":
+			if len(words) > 2 {
+				return nil, errors.Errorf("line %d: play takes only a card", number)
+			}
+			list.Plays = append(list.Plays, words[1])
+		case "buy":
+			rule, err := parseBuyRule(words[1:])
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d", number)
+			}
+			list.Buys = append(list.Buys, rule)
+		default:
+			return nil, errors.Errorf("line %d: unknown instruction %q", number, words[0])
+		}
+	}
+	return list, lines.Err()
+}
+
+func parseBuyRule(words []string) (BuyRule, error) {
+	rule := BuyRule{Card: words[0]}
+	conditions := words[1:]
+	if len(conditions)%2 != 0 {
+		return rule, errors.Errorf("buy %s: every condition needs a number", rule.Card)
+	}
+	for index := 0; index < len(conditions); index += 2 {
+		amount, err := strconv.Atoi(conditions[index+1])
+		if err != nil || amount <= 0 {
+			return rule, errors.Errorf("buy %s: %s needs a positive number, not %q", rule.Card, conditions[index], conditions[index+1])
+		}
+		switch conditions[index] {
+		case "max":
+			rule.Max = amount
+		case "coins":
+			rule.Coins = amount
+		case "endgame":
+			rule.Endgame = amount
+		default:
+			return rule, errors.Errorf("buy %s: unknown condition %q", rule.Card, conditions[index])
+		}
+	}
+	return rule, nil
+}
+
+// Write writes the list in the format ReadPriorityList reads
+func (list *PriorityList) Write(writer io.Writer) error {
+	for _, name := range list.Plays {
+		if _, err := fmt.Fprintf(writer, "play %s\n", name); err != nil {
+			return err
+		}
+	}
+	for _, rule := range list.Buys {
+		if _, err := fmt.Fprintln(writer, rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String writes the rule as a line of a priority list, like "buy reload max 2"
+func (rule BuyRule) String() string {
+	line := "buy " + rule.Card
+	for _, condition := range []struct {
+		name   string
+		amount int
+	}{{"max", rule.Max}, {"coins", rule.Coins}, {"endgame", rule.Endgame}} {
+		if condition.amount > 0 {
+			line += fmt.Sprintf(" %s %d", condition.name, condition.amount)
+		}
+	}
+	return line
+}
+
+// Check makes sure every card the list names is defined and can be played or bought
+func (list *PriorityList) Check(definitions rules.CardDefinitions) error {
+	for _, name := range list.Plays {
+		if def := definitions[name]; def == nil || !def.Is(rules.ACTION) {
+			return errors.Errorf("can't play %s, it isn't an action", name)
+		}
+	}
+	for _, rule := range list.Buys {
+		if definitions[rule.Card] == nil {
+			return errors.Errorf("can't buy %s, it isn't a card", rule.Card)
+		}
+	}
+	return nil
+}
+
+// ChooseAction plays the first card of the play list that is in hand
+func (list *PriorityList) ChooseAction(game *rules.GameState, player *rules.Player) *rules.Card {
+	for _, name := range list.Plays {
+		if card := findCard(player.Hand, name); card != nil {
+			return card
+		}
+	}
+	return nil
+}
+
+// ChooseBuy buys the first card of the buy list that is affordable and meets its conditions
+func (list *PriorityList) ChooseBuy(game *rules.GameState, player *rules.Player) *rules.Pile {
+	coins := game.StateManager.GetCoins()
+	for _, rule := range list.Buys {
+		if coins < rule.Coins || !rule.holds(game, player) {
+			continue
+		}
+		if pile := affordable(game, rule.Card, coins); pile != nil {
+			return pile
+		}
+	}
+	return nil
+}
+
+// holds checks the conditions of a rule other than coins
+func (rule BuyRule) holds(game *rules.GameState, player *rules.Player) bool {
+	if rule.Max > 0 && countCards(player, rule.Card) >= rule.Max {
+		return false
+	}
+	if rule.Endgame > 0 {
+		if pile := game.SupplyPile(rules.EVEN_MORE_ZOMBIES); pile == nil || pile.Len() > rule.Endgame {
+			return false
+		}
+	}
+	return true
+}
+
+// Decide gains the first card of the buy list on offer, otherwise answering like Big Money
+func (list *PriorityList) Decide(game *rules.GameState, decision *rules.Decision) []int {
+	if decision.Kind == rules.CHOOSE_PILE {
+		player := game.Players[decision.Player]
+		for _, rule := range list.Buys {
+			if !rule.holds(game, player) {
+				continue
+			}
+			for index, option := range decision.Options {
+				if option.Name == rule.Card {
+					return []int{index}
+				}
+			}
+		}
+	}
+	return decideByValue(game, decision)
+}
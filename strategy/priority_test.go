@@ -0,0 +1,108 @@
+package strategy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/quartermeat/card_game/rules"
+)
+
+func TestReadPriorityList(t *testing.T) {
+	list, err := ReadPriorityList(strings.NewReader(`
+# comment
+play reload
+buy even_more_zombies
+buy more_zombies endgame 4  # late
+buy reload max 2 coins 4
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Plays) != 1 || list.Plays[0] != "reload" {
+		t.Errorf("expected to play reload, got %v", list.Plays)
+	}
+	expected := []BuyRule{
+		{Card: "even_more_zombies"},
+		{Card: "more_zombies", Endgame: 4},
+		{Card: "reload", Max: 2, Coins: 4},
+	}
+	if len(list.Buys) != len(expected) {
+		t.Fatalf("expected %d buy rules, got %+v", len(expected), list.Buys)
+	}
+	for index := range expected {
+		if list.Buys[index] != expected[index] {
+			t.Errorf("rule %d: expected %+v, got %+v", index, expected[index], list.Buys[index])
+		}
+	}
+}
+
+func TestWritePriorityList(t *testing.T) {
+	list := &PriorityList{
+		Plays: []string{"reload", "hide"},
+		Buys:  []BuyRule{{Card: "even_more_zombies"}, {Card: "reload", Max: 2, Endgame: 3}, {Card: "slug", Coins: 3}},
+	}
+	var text strings.Builder
+	if err := list.Write(&text); err != nil {
+		t.Fatal(err)
+	}
+	expected := "play reload\nplay hide\nbuy even_more_zombies\nbuy reload max 2 endgame 3\nbuy slug coins 3\n"
+	if text.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, text.String())
+	}
+	read, err := ReadPriorityList(strings.NewReader(text.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read.Plays) != 2 || len(read.Buys) != 3 || read.Buys[1] != list.Buys[1] {
+		t.Errorf("expected the list to read back, got %+v", read)
+	}
+}
+
+func TestReadPriorityListErrors(t *testing.T) {
+	for _, text := range []string{"play", "play reload twice", "buy slug max", "buy slug max 0", "buy slug soon 3", "sell slug"} {
+		if _, err := ReadPriorityList(strings.NewReader(text)); err == nil {
+			t.Errorf("%q: expected an error", text)
+		}
+	}
+}
+
+func TestPriorityListCheck(t *testing.T) {
+	defs := loadDefinitions(t)
+	for text, valid := range map[string]bool{
+		"play reload\nbuy slug": true,
+		"play slug":             false,
+		"buy silver":            false,
+	} {
+		list, err := ReadPriorityList(strings.NewReader(text))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := list.Check(defs); (err == nil) != valid {
+			t.Errorf("%q: expected valid to be %v, got %v", text, valid, err)
+		}
+	}
+}
+
+func TestPriorityListBuys(t *testing.T) {
+	list, err := ReadPriorityList(strings.NewReader("buy more_zombies endgame 4\nbuy reload max 1\nbuy slug coins 4\nbuy bullet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	game := newBotGame(t, 1, list, list)
+	player := game.CurrentPlayer()
+	game.StateManager.AddCoins(5)
+	if pile := list.ChooseBuy(game, player); pile == nil || pile.Name != "reload" {
+		t.Errorf("expected reload, got %v", pile)
+	}
+
+	player.Discard.Push(game.NewCard("reload"))
+	if pile := list.ChooseBuy(game, player); pile == nil || pile.Name != "slug" {
+		t.Errorf("expected slug once reload is owned, got %v", pile)
+	}
+
+	endgame := game.SupplyPile(rules.EVEN_MORE_ZOMBIES)
+	endgame.Cards = endgame.Cards[:4]
+	if pile := list.ChooseBuy(game, player); pile == nil || pile.Name != "more_zombies" {
+		t.Errorf("expected more_zombies in the endgame, got %v", pile)
+	}
+}
@@ -0,0 +1,58 @@
+package strategy
+
+import (
+	"github.com/quartermeat/card_game/rules"
+)
+
+// Random makes a random legal move every time it is asked, drawing from the game's random
+// number generator so a seeded game plays out the same way again
+type Random struct{}
+
+// NewRandom creates a bot that plays randomly
+func NewRandom() *Random {
+	return &Random{}
+}
+
+// ChooseAction picks one of the actions in hand or stopping, all as likely as each other
+func (bot *Random) ChooseAction(game *rules.GameState, player *rules.Player) *rules.Card {
+	actions := make([]*rules.Card, 0)
+	for _, card := range player.Hand.Cards {
+		if def := game.Definition(card); def != nil && def.Is(rules.ACTION) {
+			actions = append(actions, card)
+		}
+	}
+	pick := game.Rand.Intn(len(actions) + 1)
+	if pick == len(actions) {
+		return nil
+	}
+	return actions[pick]
+}
+
+// ChooseBuy picks one of the supply piles that can be bought from or buying nothing,
+// all as likely as each other
+func (bot *Random) ChooseBuy(game *rules.GameState, player *rules.Player) *rules.Pile {
+	piles := make([]*rules.Pile, 0)
+	for _, pile := range game.Supply {
+		if affordable(game, pile.Name, game.StateManager.GetCoins()) != nil {
+			piles = append(piles, pile)
+		}
+	}
+	pick := game.Rand.Intn(len(piles) + 1)
+	if pick == len(piles) {
+		return nil
+	}
+	return piles[pick]
+}
+
+// Decide picks a random number of options between the decision's Min and Max, in a random order
+func (bot *Random) Decide(game *rules.GameState, decision *rules.Decision) []int {
+	most := decision.Max
+	if most > len(decision.Options) {
+		most = len(decision.Options)
+	}
+	count := decision.Min
+	if most > count {
+		count += game.Rand.Intn(most - count + 1)
+	}
+	return game.Rand.Perm(len(decision.Options))[:count]
+}
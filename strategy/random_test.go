@@ -0,0 +1,38 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/quartermeat/card_game/rules"
+)
+
+func TestRandomIsRepeatable(t *testing.T) {
+	standings := func() []rules.Standing {
+		bot := NewRandom()
+		game := newBotGame(t, 11, bot, bot, bot)
+		playGame(t, game, bot, bot, bot)
+		return game.Standings()
+	}
+	first, second := standings(), standings()
+	for index := range first {
+		if first[index].Player.Name != second[index].Player.Name || first[index].Score != second[index].Score || first[index].Turns != second[index].Turns {
+			t.Fatalf("expected the same seed to play out the same game, got %+v and %+v", first[index], second[index])
+		}
+	}
+}
+
+func TestRandomDecisionsAreValid(t *testing.T) {
+	bot := NewRandom()
+	game := newBotGame(t, 1, bot, bot)
+	decision := &rules.Decision{
+		Kind:    rules.CHOOSE_CARDS,
+		Options: []rules.Option{{Name: "bullet"}, {Name: "slug"}, {Name: "shells"}},
+		Min:     1,
+		Max:     5,
+	}
+	for try := 0; try < 100; try++ {
+		if err := decision.Check(bot.Decide(game, decision)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package strategy
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/quartermeat/card_game/gamestates"
+	"github.com/quartermeat/card_game/rules"
+)
+
+// Strategy decides everything an AI seat does: the actions it plays, what it buys and the
+// answer to every decision it is asked
+type Strategy interface {
+	rules.Decider
+	// ChooseAction returns the action card from the player's hand to play next, nil to stop playing actions
+	ChooseAction(game *rules.GameState, player *rules.Player) *rules.Card
+	// ChooseBuy returns the supply pile to buy from next, nil to stop buying
+	ChooseBuy(game *rules.GameState, player *rules.Player) *rules.Pile
+}
+
+// Factory builds a strategy from the argument after its name in a seat spec, which can be empty
+type Factory func(arg string, definitions rules.CardDefinitions) (Strategy, error)
+
+// DEFAULT is the strategy an "ai" seat is played by
+const DEFAULT = "big_money"
+
+var registry = map[string]Factory{
+	"random": func(arg string, definitions rules.CardDefinitions) (Strategy, error) {
+		return NewRandom(), nil
+	},
+	"big_money": func(arg string, definitions rules.CardDefinitions) (Strategy, error) {
+		if arg == "" {
+			return NewBigMoney("", 0), nil
+		}
+		if definitions[arg] == nil || !rules.IsKingdomCard(definitions[arg]) {
+			return nil, errors.Errorf("%s is not a kingdom card", arg)
+		}
+		return NewBigMoney(arg, 1), nil
+	},
+	"priority": func(arg string, definitions rules.CardDefinitions) (Strategy, error) {
+		if arg == "" {
+			return nil, errors.New("priority needs a file, like priority:assets/bots/big_money.txt")
+		}
+		list, err := LoadPriorityList(arg)
+		if err != nil {
+			return nil, err
+		}
+		return list, list.Check(definitions)
+	},
+	"mcts": func(arg string, definitions rules.CardDefinitions) (Strategy, error) {
+		if arg == "" {
+			return NewMCTS(0, 0), nil
+		}
+		if iterations, err := strconv.Atoi(arg); err == nil && iterations > 0 {
+			return NewMCTS(iterations, 0), nil
+		}
+		if budget, err := time.ParseDuration(arg); err == nil && budget > 0 {
+			return NewMCTS(0, budget), nil
+		}
+		return nil, errors.Errorf("mcts takes a number of playouts or a time, like mcts:500 or mcts:2s, not %q", arg)
+	},
+}
+
+// Register adds a named strategy that seats can be given
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// Names returns the names of the registered strategies, sorted
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New builds a strategy from a spec: a registered name, optionally followed by a colon and an
+// argument, like big_money:reload or priority:assets/bots/big_money.txt. "ai" is the DEFAULT strategy.
+func New(spec string, definitions rules.CardDefinitions) (Strategy, error) {
+	name, arg, _ := strings.Cut(spec, ":")
+	if name == "ai" {
+		name = DEFAULT
+	}
+	factory, ok := registry[name]
+	if !ok {
+		return nil, errors.Errorf("no strategy called %q, expected one of %s", name, strings.Join(Names(), ", "))
+	}
+	strategy, err := factory(arg, definitions)
+	return strategy, errors.Wrapf(err, "strategy %s", spec)
+}
+
+// TakeTurn plays the rest of the current player's turn with a strategy, returning
+// rules.ErrDecisionPending if the turn is waiting on a decision nobody has answered
+func TakeTurn(game *rules.GameState, strategy Strategy) error {
+	player := game.CurrentPlayer()
+	for game.StateManager.GetPhase() == gamestates.ActionPhase && game.StateManager.GetActions() > 0 {
+		card := strategy.ChooseAction(game, player)
+		if card == nil {
+			break
+		}
+		if err := game.PlayAction(player, card); err != nil {
+			return err
+		}
+	}
+	if err := game.PlayAllTreasures(player); err != nil {
+		return err
+	}
+	for game.StateManager.GetBuys() > 0 {
+		pile := strategy.ChooseBuy(game, player)
+		if pile == nil {
+			break
+		}
+		if err := game.Buy(player, pile); err != nil {
+			return err
+		}
+	}
+	return endTurn(game)
+}
+
+// endTurn ends the phases that are left of the turn
+func endTurn(game *rules.GameState) error {
+	seat := game.Current
+	for game.Current == seat && game.StateManager.IsTurn() {
+		if _, err := game.EndPhase(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decideByValue answers decisions the way most bots want to: discarding and trashing the
+// worst cards, gaining the most expensive, and always revealing reactions
+func decideByValue(game *rules.GameState, decision *rules.Decision) []int {
+	switch decision.Kind {
+	case rules.YES_NO:
+		return []int{rules.YES}
+	case rules.CHOOSE_PILE:
+		return []int{mostExpensive(game, decision)}
+	}
+	ranked := rankByValue(game, decision)
+	if decision.Verb() == rules.TRASH_CARDS {
+		// only infections are worth trashing
+		picks := make([]int, 0)
+		for _, index := range ranked {
+			if len(picks) < decision.Max && decision.Options[index].Name == rules.INFECTION {
+				picks = append(picks, index)
+			}
+		}
+		if len(picks) >= decision.Min {
+			return picks
+		}
+	}
+	return ranked[:decision.Min]
+}
+
+// affordable returns the named supply pile if it has cards and its card costs no more than coins
+func affordable(game *rules.GameState, name string, coins int) *rules.Pile {
+	pile := game.SupplyPile(name)
+	if pile == nil || pile.Len() == 0 || game.Definition(pile.Top()).Cost > coins {
+		return nil
+	}
+	return pile
+}
+
+func findCard(pile *rules.Pile, name string) *rules.Card {
+	if name == "" {
+		return nil
+	}
+	for _, card := range pile.Cards {
+		if card.Name == name {
+			return card
+		}
+	}
+	return nil
+}
+
+func countCards(player *rules.Player, name string) int {
+	count := 0
+	for _, card := range player.Cards() {
+		if card.Name == name {
+			count++
+		}
+	}
+	return count
+}
+
+// value is how much a card is worth keeping in hand: nothing for curses and victory cards,
+// otherwise what it costs
+func value(game *rules.GameState, name string) int {
+	def := game.Definitions[name]
+	if def == nil {
+		return 0
+	}
+	if def.Is(rules.CURSE) {
+		return -1
+	}
+	if def.Is(rules.VICTORY) && !def.Is(rules.TREASURE) && !def.Is(rules.ACTION) {
+		return 0
+	}
+	return def.Cost + 1
+}
+
+// rankByValue orders the options of a decision from least to most valuable
+func rankByValue(game *rules.GameState, decision *rules.Decision) []int {
+	ranked := make([]int, len(decision.Options))
+	for index := range ranked {
+		ranked[index] = index
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return value(game, decision.Options[ranked[i]].Name) < value(game, decision.Options[ranked[j]].Name)
+	})
+	return ranked
+}
+
+func mostExpensive(game *rules.GameState, decision *rules.Decision) int {
+	best := 0
+	for index, option := range decision.Options {
+		if value(game, option.Name) > value(game, decision.Options[best].Name) {
+			best = index
+		}
+	}
+	return best
+}
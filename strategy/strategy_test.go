@@ -0,0 +1,168 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quartermeat/card_game/gamestates"
+	"github.com/quartermeat/card_game/rules"
+)
+
+// newBotGame sets up a first_night game with a bot answering the decisions of every seat
+func newBotGame(t *testing.T, seed int64, bots ...rules.Decider) *rules.GameState {
+	t.Helper()
+	defs, err := rules.LoadCardDefinitions("../assets/cards/cardDefinitions.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	game := rules.NewGameState(defs, gamestates.NewStateManager(), seed)
+	if _, err := game.ChooseKingdom(rules.KingdomConfig{Preset: "first_night"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.SetupSupply(rules.DefaultSetup, len(bots)); err != nil {
+		t.Fatal(err)
+	}
+	for seat, bot := range bots {
+		game.AddPlayer("bot", false)
+		game.SetDecider(seat, bot)
+	}
+	game.Start()
+	return game
+}
+
+// setHand replaces the current player's hand with new cards
+func setHand(game *rules.GameState, names ...string) {
+	player := game.CurrentPlayer()
+	player.Hand.Cards = nil
+	for _, name := range names {
+		player.Hand.Push(game.NewCard(name))
+	}
+}
+
+// supplyCounts returns the number of cards left in each supply pile
+func supplyCounts(game *rules.GameState) map[string]int {
+	counts := make(map[string]int)
+	for _, pile := range game.Supply {
+		counts[pile.Name] = pile.Len()
+	}
+	return counts
+}
+
+// bought returns the names of the supply piles that shrank since before
+func bought(game *rules.GameState, before map[string]int) []string {
+	names := make([]string, 0)
+	for _, pile := range game.Supply {
+		if pile.Len() < before[pile.Name] {
+			names = append(names, pile.Name)
+		}
+	}
+	return names
+}
+
+// playGame has each seat's strategy take turns until the game is over
+func playGame(t *testing.T, game *rules.GameState, strategies ...Strategy) {
+	t.Helper()
+	for turn := 0; game.StateManager.IsTurn(); turn++ {
+		if turn > 1000 {
+			t.Fatal("expected the game to end within 1000 turns")
+		}
+		if err := TakeTurn(game, strategies[game.Current]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if game.StateManager.GetCurrentState() != gamestates.GameOver {
+		t.Errorf("expected the game to be over, got state %v", game.StateManager.GetCurrentState())
+	}
+}
+
+func loadDefinitions(t *testing.T) rules.CardDefinitions {
+	t.Helper()
+	defs, err := rules.LoadCardDefinitions("../assets/cards/cardDefinitions.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return defs
+}
+
+func TestNew(t *testing.T) {
+	defs := loadDefinitions(t)
+	for spec, check := range map[string]func(strategy Strategy) bool{
+		"ai": func(strategy Strategy) bool {
+			bot, ok := strategy.(*BigMoney)
+			return ok && bot.Kingdom == ""
+		},
+		"big_money:reload": func(strategy Strategy) bool {
+			bot, ok := strategy.(*BigMoney)
+			return ok && bot.Kingdom == "reload" && bot.KingdomCopies == 1
+		},
+		"random": func(strategy Strategy) bool {
+			_, ok := strategy.(*Random)
+			return ok
+		},
+		"priority:../assets/bots/big_money_reload.txt": func(strategy Strategy) bool {
+			list, ok := strategy.(*PriorityList)
+			return ok && len(list.Buys) > 0
+		},
+		"mcts": func(strategy Strategy) bool {
+			bot, ok := strategy.(*MCTS)
+			return ok && bot.Iterations == MCTS_ITERATIONS && bot.Budget == 0
+		},
+		"mcts:500": func(strategy Strategy) bool {
+			bot, ok := strategy.(*MCTS)
+			return ok && bot.Iterations == 500
+		},
+		"mcts:2s": func(strategy Strategy) bool {
+			bot, ok := strategy.(*MCTS)
+			return ok && bot.Iterations == 0 && bot.Budget == 2*time.Second
+		},
+	} {
+		strategy, err := New(spec, defs)
+		if err != nil {
+			t.Errorf("%s: %v", spec, err)
+		} else if !check(strategy) {
+			t.Errorf("%s: got %T %+v", spec, strategy, strategy)
+		}
+	}
+}
+
+func TestNewRejectsBadSpecs(t *testing.T) {
+	defs := loadDefinitions(t)
+	for _, spec := range []string{"human", "smart", "big_money:bullet", "big_money:nothing", "priority", "priority:missing.txt", "mcts:0", "mcts:soon"} {
+		if _, err := New(spec, defs); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("lazy", func(arg string, definitions rules.CardDefinitions) (Strategy, error) {
+		return NewBigMoney("", 0), nil
+	})
+	defer delete(registry, "lazy")
+
+	if _, err := New("lazy", loadDefinitions(t)); err != nil {
+		t.Error(err)
+	}
+	if names := Names(); len(names) != 5 || names[1] != "lazy" {
+		t.Errorf("expected lazy among the sorted names, got %v", names)
+	}
+}
+
+func TestStrategiesFinishGames(t *testing.T) {
+	defs := loadDefinitions(t)
+	specs := []string{"random", "big_money", "big_money:reload", "priority:../assets/bots/big_money_reload.txt", "mcts:10"}
+	for _, first := range specs {
+		for _, second := range specs {
+			one, err := New(first, defs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			two, err := New(second, defs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			game := newBotGame(t, 3, one, two)
+			playGame(t, game, one, two)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+	"github.com/gopxl/pixel/text"
+	"github.com/quartermeat/card_game/rules"
+	"golang.org/x/image/colornames"
+	"golang.org/x/image/font/basicfont"
+)
+
+const gameOverScale = 4
+
+var gameOverAtlas = text.NewAtlas(basicfont.Face7x13, text.ASCII)
+
+// GameOverLines lists the winners and the final standings
+func GameOverLines(game *rules.GameState) []string {
+	names := make([]string, 0)
+	for _, winner := range game.Winners() {
+		names = append(names, winner.Name)
+	}
+	lines := []string{"GAME OVER", fmt.Sprintf("winner: %s", strings.Join(names, ", "))}
+	for place, standing := range game.Standings() {
+		lines = append(lines, fmt.Sprintf("%d. %s  %d VP  %d turns", place+1, standing.Player.Name, standing.Score, standing.Turns))
+	}
+	return lines
+}
+
+// DrawGameOver draws the final standings in the middle of the window
+func DrawGameOver(win *pixelgl.Window, cam *pixel.Matrix, game *rules.GameState) {
+	txt := text.New(pixel.ZV, gameOverAtlas)
+	txt.Color = colornames.Red
+	for _, line := range GameOverLines(game) {
+		txt.Dot.X -= txt.BoundsOf(line).W() / 2
+		fmt.Fprintln(txt, line)
+	}
+	center := cam.Unproject(win.Bounds().Center())
+	txt.Draw(win, pixel.IM.Scaled(pixel.ZV, gameOverScale).Moved(center.Sub(txt.Bounds().Center().Scaled(gameOverScale))))
+}